@@ -0,0 +1 @@
+data "namep_aws_types" "example" {}
@@ -0,0 +1,11 @@
+data "namep_azure_name_availability" "storage" {
+  resource_type = "azurerm_storage_account"
+  name          = provider::namep::namestring("azurerm_storage_account", data.namep_configuration.example.configuration)
+}
+
+check "storage_name_available" {
+  assert {
+    condition     = data.namep_azure_name_availability.storage.available
+    error_message = "The storage account name is not available: ${data.namep_azure_name_availability.storage.message}"
+  }
+}
@@ -0,0 +1,17 @@
+data "namep_azure_caf_types" "example" {}
+
+data "namep_azure_locations" "example" {}
+
+data "namep_convention_file" "example" {
+  path          = "${path.module}/convention.yaml"
+  types         = data.namep_azure_caf_types.example.types
+  variable_maps = data.namep_azure_locations.example.location_maps
+  variables = {
+    env = "dev"
+    loc = "westeurope"
+  }
+}
+
+output "resource_group_name" {
+  value = provider::namep::namestring("azurerm_resource_group", data.namep_convention_file.example.configuration, { name = "main" })
+}
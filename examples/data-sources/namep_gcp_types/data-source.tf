@@ -0,0 +1 @@
+data "namep_gcp_types" "example" {}
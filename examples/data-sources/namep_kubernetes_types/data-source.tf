@@ -0,0 +1 @@
+data "namep_kubernetes_types" "example" {}
@@ -0,0 +1,28 @@
+data "namep_azure_locations" "example" {}
+
+data "namep_azure_caf_types" "example" {}
+
+data "namep_configuration" "example" {
+  variable_maps = data.namep_azure_locations.example.location_maps
+  types         = data.namep_azure_caf_types.example.types
+  formats = {
+    azure_dashes   = "#{SLUG}-#{APP}-#{ENV}-#{LOCS[LOC]}-#{NAME}"
+    azure_nodashes = "#{SLUG}#{APP}#{ENV}#{LOCS[LOC]}#{NAME}"
+  }
+
+  variables = {
+    name = "main"
+    env  = "dev"
+    app  = "myapp"
+    loc  = "westeurope"
+  }
+}
+
+data "namep_name_preview" "example" {
+  configuration  = data.namep_configuration.example.configuration
+  resource_types = ["azurerm_resource_group", "azurerm_storage_*", "azurerm_key_vault"]
+}
+
+output "names" {
+  value = data.namep_name_preview.example.names
+}
@@ -0,0 +1,15 @@
+data "namep_types_file" "example" {
+  path = "${path.module}/types.yaml"
+}
+
+data "namep_configuration" "example" {
+  types = data.namep_types_file.example.types
+  formats = {
+    custom = "#{APP}-#{NAME}"
+    vault  = "#{APP}/#{ENV}/#{NAME}"
+  }
+  variables = {
+    app = "myapp"
+    env = "dev"
+  }
+}
@@ -0,0 +1,23 @@
+data "namep_azure_locations" "example" {}
+
+data "namep_azure_caf_types" "example" {}
+
+data "namep_configuration" "example" {
+  variable_maps = data.namep_azure_locations.example.location_maps
+  types         = data.namep_azure_caf_types.example.types
+  formats = {
+    azure_dashes_subscription = "#{SLUG}-#{APP}-#{env}-#{LOCS[LOC]}-#{NAME}"
+  }
+
+  variables = {
+    name = "main"
+    env  = "dev"
+    app  = "myapp"
+    loc  = "westeurope"
+  }
+}
+
+# Shows which format was selected and where every part of the name comes from
+output "explanation" {
+  value = provider::namep::explain_name("azurerm_resource_group", data.namep_configuration.example.configuration, { name = "other" })
+}
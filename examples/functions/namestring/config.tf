@@ -10,6 +10,7 @@ variable "config" {
       max_length       = number
       lowercase        = bool
       validation_regex = string
+      cleanup_regex    = string
       default_selector = string
     }))
   })
@@ -0,0 +1,25 @@
+data "namep_azure_locations" "example" {}
+
+data "namep_azure_caf_types" "example" {}
+
+data "namep_configuration" "example" {
+  variable_maps = data.namep_azure_locations.example.location_maps
+  types         = data.namep_azure_caf_types.example.types
+  formats = {
+    azure_dashes          = "#{SLUG}-#{APP}-#{env}-#{LOCS[LOC]}-#{NAME}"
+    azure_dashes_global   = "#{SLUG}-#{APP}-#{env}-#{LOCS[LOC]}-#{NAME}-#{HASH(SUBSCRIPTION,ENV):4}"
+    azure_nodashes_global = "#{SLUG}#{APP}#{env}#{LOCS[LOC]}#{HASH(SUBSCRIPTION,ENV,NAME):6}"
+  }
+
+  variables = {
+    name         = "main"
+    env          = "dev"
+    app          = "myapp"
+    loc          = "westeurope"
+    subscription = "00000000-0000-0000-0000-000000000000"
+  }
+}
+
+output "test" {
+  value = provider::namep::namestring("azurerm_storage_account", data.namep_configuration.example.configuration)
+}
@@ -0,0 +1,31 @@
+data "namep_azure_locations" "example" {}
+
+data "namep_azure_caf_types" "example" {}
+
+data "namep_configuration" "example" {
+  variable_maps = data.namep_azure_locations.example.location_maps
+  types         = data.namep_azure_caf_types.example.types
+  formats = {
+    azure_dashes   = "#{SLUG}-#{APP}-#{env}-#{LOCS[LOC]}-#{NAME}"
+    azure_nodashes = "#{SLUG}#{APP}#{env}#{LOCS[LOC]}#{NAME}"
+  }
+
+  variables = {
+    name = "main"
+    env  = "dev"
+    app  = "myapp"
+    loc  = "westeurope"
+  }
+}
+
+locals {
+  names = provider::namep::namestrings({
+    resource_group  = { resource_type = "azurerm_resource_group" }
+    storage_account = { resource_type = "azurerm_storage_account", name = "logs" }
+    key_vault       = { resource_type = "azurerm_key_vault", loc = "northeurope" }
+  }, data.namep_configuration.example.configuration)
+}
+
+output "resource_group_name" {
+  value = local.names.resource_group
+}
@@ -0,0 +1,16 @@
+data "namep_azure_locations" "example" {}
+
+data "namep_azure_caf_types" "example" {}
+
+data "namep_configuration" "example" {
+  variable_maps = data.namep_azure_locations.example.location_maps
+  types         = data.namep_azure_caf_types.example.types
+  formats = {
+    azure_dashes = "#{SLUG}-#{APP}-#{ENV}-#{LOCS[LOC]}-#{NAME}"
+  }
+}
+
+# { APP = "myapp", ENV = "dev", LOC = "westeurope", NAME = "main" }
+output "tags" {
+  value = provider::namep::parse_name("azurerm_resource_group", "rg-myapp-dev-weu-main", data.namep_configuration.example.configuration)
+}
@@ -0,0 +1,20 @@
+data "namep_azure_caf_types" "example" {}
+
+data "namep_configuration" "example" {
+  types = data.namep_azure_caf_types.example.types
+  formats = {
+    azure_dashes = "#{SLUG}-#{APP}-#{NAME}"
+  }
+}
+
+variable "imported_storage_account_name" {
+  type    = string
+  default = "Legacy_Storage"
+}
+
+check "imported_names" {
+  assert {
+    condition     = provider::namep::validate_name("azurerm_storage_account", var.imported_storage_account_name, data.namep_configuration.example.configuration).valid
+    error_message = join(", ", provider::namep::validate_name("azurerm_storage_account", var.imported_storage_account_name, data.namep_configuration.example.configuration).errors)
+  }
+}
@@ -0,0 +1,30 @@
+data "namep_azure_caf_types" "example" {}
+
+data "namep_configuration" "example" {
+  types = data.namep_azure_caf_types.example.types
+  formats = {
+    azure_dashes = "#{SLUG}-#{APP}-#{ENV}-#{NAME}"
+  }
+  variables = {
+    app = "myapp"
+    env = "dev"
+  }
+}
+
+resource "namep_name" "main" {
+  resource_type = "azurerm_resource_group"
+  configuration = data.namep_configuration.example.configuration
+  overrides = {
+    name = "main"
+  }
+
+  # change to generate a new name with the current convention
+  keepers = {
+    generation = "1"
+  }
+}
+
+resource "azurerm_resource_group" "main" {
+  name     = namep_name.main.name
+  location = "westeurope"
+}
@@ -0,0 +1,13 @@
+resource "namep_reservation" "storage" {
+  name  = provider::namep::namestring("azurerm_storage_account", data.namep_configuration.example.configuration)
+  owner = "payments-prod"
+
+  registry = {
+    url = "https://registry.example.com/names.json"
+  }
+}
+
+resource "azurerm_storage_account" "example" {
+  name = namep_reservation.storage.name
+  # ...
+}
@@ -0,0 +1,12 @@
+resource "namep_sequence" "vm" {
+  prefix = "vm-myapp-prd-weu"
+
+  registry = {
+    file = "${path.root}/registry.json"
+  }
+}
+
+# with a format like "#{SLUG}-#{APP}-#{ENV}-#{LOCS[LOC]}-#{INSTANCE}"
+output "vm_name" {
+  value = provider::namep::namestring("azurerm_linux_virtual_machine", data.namep_configuration.example.configuration, namep_sequence.vm.variables)
+}
@@ -0,0 +1,5 @@
+package aws
+
+import "terraform-provider-namep/internal/cloud"
+
+type ResourceStructure = cloud.ResourceStructure
@@ -0,0 +1,76 @@
+package kubernetes
+
+// DNS1123Label is the rule for names which are used as DNS labels (e.g. namespaces), see
+// https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#dns-label-names
+var DNS1123Label = Rule{
+	Selector:         "k8s_label",
+	MinLength:        1,
+	MaxLength:        63,
+	LowerCase:        true,
+	RegEx:            "[^a-z0-9-]",
+	ValidationRegExp: "^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$",
+}
+
+// RFC1035Label is the rule for names which must also start with a letter (e.g. services), see
+// https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#rfc-1035-label-names
+var RFC1035Label = Rule{
+	Selector:         "k8s_label",
+	MinLength:        1,
+	MaxLength:        63,
+	LowerCase:        true,
+	RegEx:            "[^a-z0-9-]",
+	ValidationRegExp: "^[a-z]([a-z0-9-]{0,61}[a-z0-9])?$",
+}
+
+// DNS1123Subdomain is the rule for most object names, see
+// https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#dns-subdomain-names
+//
+// The total length cannot be expressed in the regex (RE2 has no lookahead), it is enforced by max_length which is checked on its own.
+var DNS1123Subdomain = Rule{
+	Selector:         "k8s_subdomain",
+	MinLength:        1,
+	MaxLength:        253,
+	LowerCase:        true,
+	RegEx:            "[^a-z0-9.-]",
+	ValidationRegExp: `^[a-z0-9]([a-z0-9-]*[a-z0-9])?(\.[a-z0-9]([a-z0-9-]*[a-z0-9])?)*$`,
+}
+
+// LabelValue is the rule for label values, see
+// https://kubernetes.io/docs/concepts/overview/working-with-objects/labels/#syntax-and-character-set
+var LabelValue = Rule{
+	Selector:         "k8s_label_value",
+	MinLength:        0,
+	MaxLength:        63,
+	LowerCase:        false,
+	RegEx:            "[^A-Za-z0-9_.-]",
+	ValidationRegExp: "^([A-Za-z0-9]([A-Za-z0-9_.-]{0,61}[A-Za-z0-9])?)?$",
+}
+
+// ResourceDefinitions are a map of definitions for the objects supported, named after the resources of the kubernetes provider
+var ResourceDefinitions = map[string]ResourceStructure{
+	"kubernetes_cluster_role":              {"kubernetes_cluster_role", "crole", DNS1123Subdomain},
+	"kubernetes_cluster_role_binding":      {"kubernetes_cluster_role_binding", "crb", DNS1123Subdomain},
+	"kubernetes_config_map":                {"kubernetes_config_map", "cm", DNS1123Subdomain},
+	"kubernetes_cron_job":                  {"kubernetes_cron_job", "cj", Rule{"k8s_label", 1, 52, true, "[^a-z0-9-]", "^[a-z0-9]([a-z0-9-]{0,50}[a-z0-9])?$"}},
+	"kubernetes_daemonset":                 {"kubernetes_daemonset", "ds", DNS1123Subdomain},
+	"kubernetes_deployment":                {"kubernetes_deployment", "deploy", DNS1123Subdomain},
+	"kubernetes_horizontal_pod_autoscaler": {"kubernetes_horizontal_pod_autoscaler", "hpa", DNS1123Subdomain},
+	"kubernetes_ingress_v1":                {"kubernetes_ingress_v1", "ing", DNS1123Subdomain},
+	"kubernetes_job":                       {"kubernetes_job", "job", DNS1123Label},
+	"kubernetes_label_value":               {"kubernetes_label_value", "", LabelValue},
+	"kubernetes_limit_range":               {"kubernetes_limit_range", "limits", DNS1123Subdomain},
+	"kubernetes_namespace":                 {"kubernetes_namespace", "ns", DNS1123Label},
+	"kubernetes_network_policy":            {"kubernetes_network_policy", "netpol", DNS1123Subdomain},
+	"kubernetes_persistent_volume":         {"kubernetes_persistent_volume", "pv", DNS1123Subdomain},
+	"kubernetes_persistent_volume_claim":   {"kubernetes_persistent_volume_claim", "pvc", DNS1123Subdomain},
+	"kubernetes_pod":                       {"kubernetes_pod", "pod", DNS1123Subdomain},
+	"kubernetes_priority_class":            {"kubernetes_priority_class", "pc", DNS1123Subdomain},
+	"kubernetes_resource_quota":            {"kubernetes_resource_quota", "quota", DNS1123Subdomain},
+	"kubernetes_role":                      {"kubernetes_role", "role", DNS1123Subdomain},
+	"kubernetes_role_binding":              {"kubernetes_role_binding", "rb", DNS1123Subdomain},
+	"kubernetes_secret":                    {"kubernetes_secret", "secret", DNS1123Subdomain},
+	"kubernetes_service":                   {"kubernetes_service", "svc", RFC1035Label},
+	"kubernetes_service_account":           {"kubernetes_service_account", "sa", DNS1123Subdomain},
+	"kubernetes_stateful_set":              {"kubernetes_stateful_set", "sts", DNS1123Label},
+	"kubernetes_storage_class":             {"kubernetes_storage_class", "sc", DNS1123Subdomain},
+}
@@ -0,0 +1,26 @@
+package kubernetes
+
+// Rule is one of the naming rules Kubernetes applies to object names and labels
+type Rule struct {
+	// Selector used as the default selector of the types following the rule
+	Selector string
+	// MinLength attribute define the minimum length of the name
+	MinLength int
+	// MaxLength attribute define the maximum length of the name
+	MaxLength int
+	// enforce lowercase
+	LowerCase bool
+	// Regular expression matching the characters which are not allowed
+	RegEx string
+	// the Regular expression to validate the generated string
+	ValidationRegExp string
+}
+
+type ResourceStructure struct {
+	// Resource type name
+	ResourceTypeName string
+	// Resource prefix used for the SLUG token
+	Slug string
+	// The rule the name of the resource must follow
+	Rule Rule
+}
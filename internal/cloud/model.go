@@ -0,0 +1,23 @@
+package cloud
+
+// ResourceStructure is the naming rule of a resource type in the datasets of the AWS and GCP types.
+type ResourceStructure struct {
+	// Resource type name
+	ResourceTypeName string `json:"name"`
+	// Resource prefix used for the SLUG token
+	Slug string `json:"slug,omitempty"`
+	// MinLength attribute define the minimum length of the name
+	MinLength int `json:"min_length"`
+	// MaxLength attribute define the maximum length of the name
+	MaxLength int `json:"max_length"`
+	// enforce lowercase
+	LowerCase bool `json:"lowercase,omitempty"`
+	// Regular expression to apply to the resource type
+	RegEx string `json:"regex,omitempty"`
+	// the Regular expression to validate the generated string
+	ValidationRegExp string `json:"validation_regex,omitempty"`
+	// can the resource include dashes
+	Dashes bool `json:"dashes"`
+	// The scope of this name where it needs to be unique
+	Scope string `json:"scope,omitempty"`
+}
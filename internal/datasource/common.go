@@ -1,7 +1,18 @@
 package datasource
 
 import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"terraform-provider-namep/internal/cloud"
+	"terraform-provider-namep/internal/cloud/aws"
+	"terraform-provider-namep/internal/cloud/gcp"
+	"terraform-provider-namep/internal/shared"
+
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -14,7 +25,106 @@ func typesAttributes() types.ObjectType {
 			"max_length":       types.Int32Type,
 			"lowercase":        types.BoolType,
 			"validation_regex": types.StringType,
+			"cleanup_regex":    types.StringType,
 			"default_selector": types.StringType,
 		},
 	}
 }
+
+// sanitizeDescription returns the "Sanitize" section of the description of a types data source, cleanupRegex describes what the
+// cleanup_regex of the types is set to.
+func sanitizeDescription(cleanupRegex string) string {
+	return `## Sanitize
+
+If the ` + "`sanitize`" + ` field is true, the ` + "`cleanup_regex`" + ` of each type will be set to ` + cleanupRegex + `.  This causes the ` + "`namestring`" + ` function to clean the computed name before validating it: the name is
+converted to lowercase (if the type requires it), all characters matching ` + "`cleanup_regex`" + ` are removed and the name is truncated to ` + "`max_length`" + `.  See the [namestring function documentation](../functions/namestring.md) for details.
+`
+}
+
+// sanitizeAttribute returns the sanitize attribute of a types data source, regexName names the regex which is used as the cleanup_regex.
+func sanitizeAttribute(regexName string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Description: fmt.Sprintf("Sanitize flag to determine if the types should include the %s so that names are cleaned instead of rejected by the `namestring` function, defaults to false.", regexName),
+		Required:    false,
+		Optional:    true,
+	}
+}
+
+// cloudTypes converts the definitions of the AWS or GCP dataset, the default selectors start with selectorPrefix.
+func cloudTypes(defs map[string]cloud.ResourceStructure, selectorPrefix string, sanitize bool) map[string]shared.TypeFields {
+	typeInfoMap := make(map[string]shared.TypeFields, len(defs))
+
+	for _, def := range defs {
+		typeInfoMap[def.ResourceTypeName] = cloudToSharedTypeFields(def, selectorPrefix, sanitize)
+	}
+
+	return typeInfoMap
+}
+
+func cloudToSharedTypeFields(def cloud.ResourceStructure, selectorPrefix string, sanitize bool) shared.TypeFields {
+	dashes := "nodashes"
+	if def.Dashes {
+		dashes = "dashes"
+	}
+	cleanupRegex := ""
+
+	if sanitize {
+		cleanupRegex = def.RegEx
+	}
+
+	return shared.TypeFields{
+		Name:            def.ResourceTypeName,
+		Slug:            def.Slug,
+		MinLength:       def.MinLength,
+		MaxLength:       def.MaxLength,
+		Lowercase:       def.LowerCase,
+		ValidationRegex: def.ValidationRegExp,
+		CleanupRegex:    cleanupRegex,
+		DefaultSelector: fmt.Sprintf("%s_%s_%s", selectorPrefix, dashes, def.Scope),
+	}
+}
+
+// TypesSources are the names of the types built into the provider, which can be used as the default types of the provider configuration.
+var TypesSources = []string{"azure_caf", "aws", "gcp", "kubernetes"}
+
+// StaticTypes returns the types built into the provider for a source in TypesSources, the same types as the static setting of the data source
+// of the source returns.
+func StaticTypes(source string) map[string]shared.TypeFields {
+	switch source {
+	case "azure_caf":
+		return azureCafStaticTypes(false)
+	case "aws":
+		return cloudTypes(aws.ResourceDefinitions, "aws", false)
+	case "gcp":
+		return cloudTypes(gcp.ResourceDefinitions, "gcp", false)
+	case "kubernetes":
+		return kubernetesTypes(false)
+	}
+
+	return nil
+}
+
+// LoadTypesFile loads the types of a YAML or JSON file in the format of the namep_types_file data source.  Every problem found is returned.
+func LoadTypesFile(filename string) (map[string]shared.TypeFields, []string) {
+	content, err := os.ReadFile(filename)
+
+	if err != nil {
+		return nil, []string{err.Error()}
+	}
+
+	return loadTypes(filename, content)
+}
+
+// mergeFold copies the entries of src into dst.  Variables and variable maps are looked up by their uppercased name, so an entry of dst
+// whose name only differs in case is replaced instead of kept next to the new entry.
+func mergeFold[V any](dst map[string]V, src map[string]V) {
+	for _, k := range slices.Sorted(maps.Keys(src)) {
+		for existing := range dst {
+			if existing != k && strings.EqualFold(existing, k) {
+				delete(dst, existing)
+			}
+		}
+
+		dst[k] = src[k]
+	}
+}
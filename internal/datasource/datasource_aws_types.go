@@ -0,0 +1,79 @@
+package datasource
+
+import (
+	"context"
+
+	"terraform-provider-namep/internal/cloud/aws"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource = &awsTypesDataSource{}
+)
+
+// New is a helper function to simplify the provider implementation.
+func NewAwsTypes() datasource.DataSource {
+	return &awsTypesDataSource{}
+}
+
+// data source implementation.
+type awsTypesDataSource struct{}
+
+type awsTypesDataSourceModel struct {
+	Sanitize types.Bool `tfsdk:"sanitize"`
+	Types    types.Map  `tfsdk:"types"`
+}
+
+func (d *awsTypesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_aws_types"
+}
+
+func (d *awsTypesDataSource) Schema(ctx context.Context, ds datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `This data resource creates a map of AWS resource type names to type information.  The types are built into the provider (there is no
+upstream project publishing AWS naming rules in a machine readable form), so they are always static and cannot change without a new version of the provider.
+
+The purpose of this data source is for creating the types to to be passed to the ` + "`types`" + ` parameter in the [namep_configuration](configuration.md) data source.  Alternatively, it could be assigned to a ` + "`locals`" + ` variable to 
+add other types for the ` + "`types`" + ` parameter, or merged with the types of the [namep_azure_caf_types](azure_caf_types.md) data source.
+
+## Default Selector
+
+The ` + "`defaultSelector`" + ` for this resource is made up of 3 components: the word "aws", the word "dashes" or "nodashes" (depending on if dashes are allowed in the name of the resource type), and the ` + "`scope`" + ` of the resource.
+The main ` + "`scope`" + ` to be concerned about is the "global" scope (e.g. S3 buckets), which means the name must be unique across all of AWS.  The other scopes are "account" (e.g. IAM roles), "region" and "parent" (e.g. subnets in a VPC).
+
+` + sanitizeDescription("the regex matching the characters which are not allowed in the name"),
+		Attributes: map[string]schema.Attribute{
+			"sanitize": sanitizeAttribute("cleanup regex"),
+			"types": schema.MapAttribute{
+				Description: "The type info map of the AWS resource types.",
+				Computed:    true,
+				ElementType: typesAttributes(),
+			},
+		},
+	}
+}
+
+func (d *awsTypesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config awsTypesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	result, diag := types.MapValueFrom(ctx, typesAttributes(), cloudTypes(aws.ResourceDefinitions, "aws", config.Sanitize.ValueBool()))
+
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag.Errors()...)
+		return
+	}
+
+	config.Types = result
+
+	tflog.Trace(ctx, "read aws type data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
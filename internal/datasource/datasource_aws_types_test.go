@@ -0,0 +1,82 @@
+package datasource_test
+
+import (
+	"terraform-provider-namep/internal/acctest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccDataSourceAwsTypes_read(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "namep_aws_types" "example" {}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.namep_aws_types.example",
+						tfjsonpath.New("types"),
+						knownvalue.MapPartial(map[string]knownvalue.Check{
+							"aws_s3_bucket": knownvalue.ObjectPartial(map[string]knownvalue.Check{
+								"name":             knownvalue.StringExact("aws_s3_bucket"),
+								"slug":             knownvalue.StringExact("s3"),
+								"min_length":       knownvalue.Int64Exact(3),
+								"max_length":       knownvalue.Int64Exact(63),
+								"lowercase":        knownvalue.Bool(true),
+								"cleanup_regex":    knownvalue.StringExact(""),
+								"default_selector": knownvalue.StringExact("aws_dashes_global"),
+							}),
+							"aws_iam_role": knownvalue.ObjectPartial(map[string]knownvalue.Check{
+								"max_length":       knownvalue.Int64Exact(64),
+								"default_selector": knownvalue.StringExact("aws_dashes_account"),
+							}),
+						}),
+					),
+				},
+			},
+		},
+	})
+}
+
+func TestAccDataSourceAwsTypes_sanitize(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "namep_aws_types" "example" {
+					sanitize = true
+				}
+
+				data "namep_configuration" "example" {
+				  types = data.namep_aws_types.example.types
+				  formats = {
+				    aws_dashes = "#{APP}-#{NAME}-#{SLUG}"
+				  }
+				  variables = {
+				    app = "My_App"
+				  }
+				}
+
+				output "bucket" {
+				  value = provider::namep::namestring("aws_s3_bucket", data.namep_configuration.example.configuration, { name = "logs" })
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.namep_aws_types.example",
+						tfjsonpath.New("types"),
+						knownvalue.MapPartial(map[string]knownvalue.Check{
+							"aws_s3_bucket": knownvalue.ObjectPartial(map[string]knownvalue.Check{
+								"cleanup_regex": knownvalue.StringExact("[^a-z0-9.-]"),
+							}),
+						}),
+					),
+					statecheck.ExpectKnownOutputValue("bucket", knownvalue.StringExact("myapp-logs-s3")),
+				},
+			},
+		},
+	})
+}
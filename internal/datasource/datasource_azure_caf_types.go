@@ -37,10 +37,11 @@ type azureCafTypesDataSource struct {
 }
 
 type azureCafTypesDataSourceModel struct {
-	Version types.String `tfsdk:"version"`
-	Static  types.Bool   `tfsdk:"static"`
-	Source  types.String `tfsdk:"source"`
-	Types   types.Map    `tfsdk:"types"`
+	Version  types.String `tfsdk:"version"`
+	Static   types.Bool   `tfsdk:"static"`
+	Sanitize types.Bool   `tfsdk:"sanitize"`
+	Source   types.String `tfsdk:"source"`
+	Types    types.Map    `tfsdk:"types"`
 }
 
 func (d *azureCafTypesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -69,7 +70,8 @@ add other types for the ` + "`types`" + ` parameter.
 The ` + "`defaultSelector`" + ` for this resource is made up of 3 components: the word "azure", the word "dashes" or "nodashes" (depending on if dashes are allowed in the name of the resource type), and the ` + "`scope`" + ` of the resource.
 The main ` + "`scope`" + ` to be concerned about is the "global" scope, which means the name must be unique across all of Azure.  The other scopes are "subscription", "resourceGroup", and "resource".  When using the ` + "`defaultSelector`" + ` to set
 formats for the resources, it is recommended to use at least the first 2 components (e.g. "azure_dashes") since some names cannot have dashes and should have a different format than those which can.
-`,
+
+` + sanitizeDescription("the CAF `regex` for that resource type"),
 		Attributes: map[string]schema.Attribute{
 			"static": schema.BoolAttribute{
 				Description: "Static flag to determine if the data source should use data retrieved when this data source was built.  If false, the data source will be downloaded from the Azure CAF project.",
@@ -82,6 +84,7 @@ formats for the resources, it is recommended to use at least the first 2 compone
 				Required: false,
 				Optional: true,
 			},
+			"sanitize": sanitizeAttribute("CAF cleanup regex"),
 			"source": schema.StringAttribute{
 				Description: "The source URL the Azure CAF types were loaded from.",
 				Computed:    true,
@@ -129,16 +132,13 @@ func (d *azureCafTypesDataSource) Read(ctx context.Context, req datasource.ReadR
 
 	var source string
 	var typeInfoMap map[string]shared.TypeFields
+	sanitize := config.Sanitize.ValueBool()
 
 	if d.static || config.Static.ValueBool() {
 		source = "static"
-		typeInfoMap = make(map[string]shared.TypeFields, len(azure.ResourceDefinitions))
-
-		for _, def := range azure.ResourceDefinitions {
-			typeInfoMap[def.ResourceTypeName] = toSharedTypeFields(def, false)
-		}
+		typeInfoMap = azureCafStaticTypes(sanitize)
 	} else {
-		source, typeInfoMap = getTypeInfoMap(config.Version, &resp.Diagnostics)
+		source, typeInfoMap = getTypeInfoMap(config.Version, sanitize, &resp.Diagnostics)
 
 		if resp.Diagnostics.HasError() {
 			return
@@ -164,7 +164,17 @@ func (d *azureCafTypesDataSource) Read(ctx context.Context, req datasource.ReadR
 	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
 }
 
-func getTypeInfoMap(version types.String, diags *diag.Diagnostics) (string, map[string]shared.TypeFields) {
+func azureCafStaticTypes(sanitize bool) map[string]shared.TypeFields {
+	typeInfoMap := make(map[string]shared.TypeFields, len(azure.ResourceDefinitions))
+
+	for _, def := range azure.ResourceDefinitions {
+		typeInfoMap[def.ResourceTypeName] = toSharedTypeFields(def, false, sanitize)
+	}
+
+	return typeInfoMap
+}
+
+func getTypeInfoMap(version types.String, sanitize bool, diags *diag.Diagnostics) (string, map[string]shared.TypeFields) {
 	cafUrl, err := getResourceFileStrings(version)
 
 	if err != nil {
@@ -185,7 +195,7 @@ func getTypeInfoMap(version types.String, diags *diag.Diagnostics) (string, map[
 	typeInfoMap := make(map[string]shared.TypeFields, len(defs))
 
 	for _, def := range defs {
-		typeInfoMap[def.ResourceTypeName] = toSharedTypeFields(def, true)
+		typeInfoMap[def.ResourceTypeName] = toSharedTypeFields(def, true, sanitize)
 	}
 
 	return cafUrl, typeInfoMap
@@ -213,21 +223,24 @@ func getResourceFileStrings(versionString types.String) (string, error) {
 	return caf, nil
 }
 
-func toSharedTypeFields(def azure.ResourceStructure, unquoteRegex bool) shared.TypeFields {
+func toSharedTypeFields(def azure.ResourceStructure, unquoteRegex bool, sanitize bool) shared.TypeFields {
 	dashes := "nodashes"
 	if def.Dashes {
 		dashes = "dashes"
 	}
 	defaultSelector := fmt.Sprintf("azure_%s_%s", dashes, def.Scope)
 	validationRegex := def.ValidationRegExp
+	cleanupRegex := ""
+
+	if sanitize {
+		cleanupRegex = def.RegEx
+	}
 
 	if unquoteRegex {
-		var err error
-		validationRegex, err = strconv.Unquote(def.ValidationRegExp)
+		validationRegex = unquote(def.ValidationRegExp, "validation regex")
 
-		if err != nil {
-			tflog.Error(context.Background(), fmt.Sprintf("Failed to unquote validation regex: %v", err))
-			validationRegex = def.ValidationRegExp
+		if sanitize {
+			cleanupRegex = unquote(def.RegEx, "cleanup regex")
 		}
 	}
 
@@ -238,6 +251,22 @@ func toSharedTypeFields(def azure.ResourceStructure, unquoteRegex bool) shared.T
 		MaxLength:       def.MaxLength,
 		Lowercase:       def.LowerCase,
 		ValidationRegex: validationRegex,
+		CleanupRegex:    cleanupRegex,
 		DefaultSelector: defaultSelector,
 	}
 }
+
+func unquote(regex string, kind string) string {
+	if regex == "" {
+		return regex
+	}
+
+	result, err := strconv.Unquote(regex)
+
+	if err != nil {
+		tflog.Error(context.Background(), fmt.Sprintf("Failed to unquote %s: %v", kind, err))
+		return regex
+	}
+
+	return result
+}
@@ -66,4 +66,29 @@ func TestAccDataSourceAzureCafTypes_static_provider(t *testing.T) {
 			},
 		},
 	})
-}
\ No newline at end of file
+}
+func TestAccDataSourceAzureCafTypes_sanitize(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "namep_azure_caf_types" "example" {
+					static = true
+					sanitize = true
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.namep_azure_caf_types.example",
+						tfjsonpath.New("types"),
+						knownvalue.MapPartial(map[string]knownvalue.Check{
+							"aks_node_pool_linux": knownvalue.ObjectPartial(map[string]knownvalue.Check{
+								"name":          knownvalue.StringExact("aks_node_pool_linux"),
+								"cleanup_regex": knownvalue.StringExact("[^0-9a-z]"),
+							}),
+						}),
+					),
+				},
+			},
+		},
+	})
+}
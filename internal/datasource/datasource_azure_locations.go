@@ -9,6 +9,7 @@ import (
 	"terraform-provider-namep/internal/shared"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armsubscriptions"
 	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
@@ -27,6 +28,11 @@ var (
 	_ datasource.DataSourceWithConfigValidators = &azureLocationsDataSource{}
 )
 
+// newAzureCredential creates the credential used for all Azure requests (replaced by the tests, which run against a fake ARM server).
+var newAzureCredential = func(options *azidentity.DefaultAzureCredentialOptions) (azcore.TokenCredential, error) {
+	return azidentity.NewDefaultAzureCredential(options)
+}
+
 // New is a helper function to simplify the provider implementation.
 func NewAzureLocations() datasource.DataSource {
 	return &azureLocationsDataSource{}
@@ -66,7 +72,7 @@ the same as the ISO 3166-1 alpha-2 code).
 ## locs_from_display_name
 
 This is a map from the lowercase display name of the location (e.g. "east us") to the Azure location name (e.g. "eastus").  This is useful for users that want to use the display name in their configuration but need the Azure location name.
-Note this cannot be used to go from display name to short name since the ` + "`namestring`" + ` function does not support double map lookups.
+To go from the display name directly to the short name, the lookups can be nested (e.g. ` + "`#{LOCS[LOCS_FROM_DISPLAY_NAME[LOC]]}`" + `).
 
 ## Common use
 
@@ -177,13 +183,13 @@ func createStaticLocationMaps() (string, map[string]map[string]string) {
 func createLocationMaps(ctx context.Context, subscriptionID types.String, subscriptionName types.String, diags *diag.Diagnostics) (string, map[string]map[string]string) {
 	locations := make(map[string]map[string]string)
 
-	cred, err := azidentity.NewDefaultAzureCredential(nil)
+	cred, err := newAzureCredential(nil)
 	if err != nil {
 		diags.AddError("Failed to obtain a credential", fmt.Sprintf("failed to obtain a credential: %v", err))
 		return "", locations
 	}
 
-	subsrId, err := subscriptionId(ctx, cred, subscriptionID, subscriptionName)
+	subsrId, err := subscriptionId(ctx, cred, nil, subscriptionID, subscriptionName)
 	if err != nil {
 		diags.AddError("failed to get subscription ID", fmt.Sprintf("failed to get subscription ID: %v", err))
 		return subsrId, locations
@@ -213,12 +219,12 @@ func createLocationMaps(ctx context.Context, subscriptionID types.String, subscr
 	return subsrId, locations
 }
 
-func subscriptionId(ctx context.Context, cred azcore.TokenCredential, subscriptionId types.String, subscriptionName types.String) (string, error) {
+func subscriptionId(ctx context.Context, cred azcore.TokenCredential, options *arm.ClientOptions, subscriptionId types.String, subscriptionName types.String) (string, error) {
 	if !subscriptionId.IsNull() {
 		return subscriptionId.ValueString(), nil
 	}
 
-	clientFactory, err := armsubscriptions.NewClientFactory(cred, nil)
+	clientFactory, err := armsubscriptions.NewClientFactory(cred, options)
 	if err != nil {
 		tflog.Error(ctx, fmt.Sprintf("failed to create client: %v", err))
 		return "", err
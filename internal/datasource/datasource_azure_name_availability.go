@@ -0,0 +1,288 @@
+package datasource
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                     = &azureNameAvailabilityDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &azureNameAvailabilityDataSource{}
+)
+
+// armClientVersion is the version reported in the user agent of the requests to ARM.
+const armClientVersion = "v1.0.0"
+
+// nameAvailabilityCheck is the checkNameAvailability operation of a resource provider.
+type nameAvailabilityCheck struct {
+	namespace    string
+	resourceType string
+	apiVersion   string
+}
+
+// nameAvailabilityChecks are the checks for the resource types with globally unique names, by resource type.
+var nameAvailabilityChecks = map[string]nameAvailabilityCheck{
+	"azurerm_storage_account":      {"Microsoft.Storage", "Microsoft.Storage/storageAccounts", "2023-05-01"},
+	"azurerm_key_vault":            {"Microsoft.KeyVault", "Microsoft.KeyVault/vaults", "2023-07-01"},
+	"azurerm_container_registry":   {"Microsoft.ContainerRegistry", "Microsoft.ContainerRegistry/registries", "2023-07-01"},
+	"azurerm_app_service":          {"Microsoft.Web", "Microsoft.Web/sites", "2023-12-01"},
+	"azurerm_function_app":         {"Microsoft.Web", "Microsoft.Web/sites", "2023-12-01"},
+	"azurerm_linux_web_app":        {"Microsoft.Web", "Microsoft.Web/sites", "2023-12-01"},
+	"azurerm_windows_web_app":      {"Microsoft.Web", "Microsoft.Web/sites", "2023-12-01"},
+	"azurerm_linux_function_app":   {"Microsoft.Web", "Microsoft.Web/sites", "2023-12-01"},
+	"azurerm_windows_function_app": {"Microsoft.Web", "Microsoft.Web/sites", "2023-12-01"},
+	"azurerm_search_service":       {"Microsoft.Search", "searchServices", "2023-11-01"},
+	"azurerm_servicebus_namespace": {"Microsoft.ServiceBus", "Microsoft.ServiceBus/namespaces", "2021-11-01"},
+	"azurerm_eventhub_namespace":   {"Microsoft.EventHub", "Microsoft.EventHub/namespaces", "2024-01-01"},
+}
+
+func NewAzureNameAvailability() datasource.DataSource {
+	return &azureNameAvailabilityDataSource{}
+}
+
+// data source implementation.
+type azureNameAvailabilityDataSource struct {
+}
+
+type azureNameAvailabilityDataSourceModel struct {
+	ResourceType     types.String `tfsdk:"resource_type"`
+	Name             types.String `tfsdk:"name"`
+	SubscriptionID   types.String `tfsdk:"subscription_id"`
+	SubscriptionName types.String `tfsdk:"subscription_display_name"`
+	Endpoint         types.String `tfsdk:"endpoint"`
+	Available        types.Bool   `tfsdk:"available"`
+	Reason           types.String `tfsdk:"reason"`
+	Message          types.String `tfsdk:"message"`
+}
+
+func (d *azureNameAvailabilityDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_azure_name_availability"
+}
+
+func (d *azureNameAvailabilityDataSource) Schema(ctx context.Context, ds datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `This data source checks if a name is still available in Azure for resource types whose names must be globally unique (e.g. storage accounts or key vaults).  A name can pass
+the validation of the [namestring function](../functions/namestring.md) and still be taken by someone else, which is otherwise only found out when the resource is created.
+
+The name is checked with the ` + "`checkNameAvailability`" + ` operation of the resource provider, in the specified (or active if none specified) Azure subscription.  The supported resource types are
+` + supportedResourceTypes() + `.
+
+Note that a name is reported as not available once the resource exists, including when it was created by this configuration, so the result is usually checked before the resource is created
+(e.g. in a ` + "`check`" + ` block or a precondition).`,
+		Attributes: map[string]schema.Attribute{
+			"resource_type": schema.StringAttribute{
+				Description: "The resource type of the name to check (e.g. `azurerm_storage_account`).",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(sortedKeys(nameAvailabilityChecks)...),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name to check.",
+				Required:    true,
+			},
+			"subscription_id": schema.StringAttribute{
+				Description: "Subscription ID to check the name in (cannot be used with `subscription_display_name`).",
+				Optional:    true,
+			},
+			"subscription_display_name": schema.StringAttribute{
+				Description: "Subscription Display Name to check the name in (cannot be used with `subscription_id`).",
+				Optional:    true,
+			},
+			"endpoint": schema.StringAttribute{
+				Description: "The Azure Resource Manager endpoint to use, defaults to `https://management.azure.com/`.  The endpoints of the Azure Government and Azure China clouds are recognized and " +
+					"their credentials requested accordingly.  The endpoint must use https, unless it is on the local host (e.g. a fake ARM server for testing).",
+				Optional: true,
+			},
+			"available": schema.BoolAttribute{
+				Description: "True if the name is available.",
+				Computed:    true,
+			},
+			"reason": schema.StringAttribute{
+				Description: "The reason the name is not available (`Invalid` or `AlreadyExists`), empty if it is available.",
+				Computed:    true,
+			},
+			"message": schema.StringAttribute{
+				Description: "The message of the resource provider explaining why the name is not available, empty if it is available.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *azureNameAvailabilityDataSource) ConfigValidators(context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.Conflicting(
+			path.MatchRoot("subscription_id"),
+			path.MatchRoot("subscription_display_name"),
+		),
+	}
+}
+
+func (d *azureNameAvailabilityDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config azureNameAvailabilityDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	check := nameAvailabilityChecks[config.ResourceType.ValueString()]
+
+	clientOptions, credentialOptions, err := armOptions(config.Endpoint)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("endpoint"), "Invalid endpoint", err.Error())
+		return
+	}
+
+	cred, err := newAzureCredential(credentialOptions)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to obtain a credential", fmt.Sprintf("failed to obtain a credential: %v", err))
+		return
+	}
+
+	subsrId, err := subscriptionId(ctx, cred, clientOptions, config.SubscriptionID, config.SubscriptionName)
+	if err != nil {
+		resp.Diagnostics.AddError("failed to get subscription ID", fmt.Sprintf("failed to get subscription ID: %v", err))
+		return
+	}
+
+	result, err := checkNameAvailability(ctx, cred, clientOptions, subsrId, check, config.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to check name availability", fmt.Sprintf("failed to check the availability of %q: %v", config.Name.ValueString(), err))
+		return
+	}
+
+	config.Available = types.BoolValue(result.NameAvailable)
+	config.Reason = types.StringValue(result.Reason)
+	config.Message = types.StringValue(result.Message)
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "read azure name availability data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+type nameAvailabilityResult struct {
+	NameAvailable bool   `json:"nameAvailable"`
+	Reason        string `json:"reason"`
+	Message       string `json:"message"`
+}
+
+func checkNameAvailability(ctx context.Context, cred azcore.TokenCredential, options *arm.ClientOptions, subscriptionId string, check nameAvailabilityCheck, name string) (nameAvailabilityResult, error) {
+	var result nameAvailabilityResult
+
+	client, err := arm.NewClient("namep", armClientVersion, cred, options)
+	if err != nil {
+		return result, err
+	}
+
+	urlPath := runtime.JoinPaths(client.Endpoint(), "subscriptions", url.PathEscape(subscriptionId), "providers", check.namespace, "checkNameAvailability")
+
+	req, err := runtime.NewRequest(ctx, http.MethodPost, urlPath)
+	if err != nil {
+		return result, err
+	}
+
+	query := req.Raw().URL.Query()
+	query.Set("api-version", check.apiVersion)
+	req.Raw().URL.RawQuery = query.Encode()
+	req.Raw().Header["Accept"] = []string{"application/json"}
+
+	if err := runtime.MarshalAsJSON(req, map[string]string{"name": name, "type": check.resourceType}); err != nil {
+		return result, err
+	}
+
+	resp, err := client.Pipeline().Do(req)
+	if err != nil {
+		return result, err
+	}
+
+	if !runtime.HasStatusCode(resp, http.StatusOK) {
+		return result, runtime.NewResponseError(resp)
+	}
+
+	err = runtime.UnmarshalAsJSON(resp, &result)
+
+	return result, err
+}
+
+// armOptions returns the options of the ARM clients and of the credential for the endpoint, or nil for both if no endpoint is configured.
+func armOptions(endpoint types.String) (*arm.ClientOptions, *azidentity.DefaultAzureCredentialOptions, error) {
+	if endpoint.IsNull() {
+		return nil, nil, nil
+	}
+
+	u, err := url.Parse(endpoint.ValueString())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	loopback := u.Hostname() == "localhost" || net.ParseIP(u.Hostname()).IsLoopback()
+
+	if u.Scheme != "https" && !(u.Scheme == "http" && loopback) {
+		return nil, nil, fmt.Errorf("endpoint %q must use https", endpoint.ValueString())
+	}
+
+	for _, c := range []cloud.Configuration{cloud.AzurePublic, cloud.AzureGovernment, cloud.AzureChina} {
+		if strings.TrimSuffix(c.Services[cloud.ResourceManager].Endpoint, "/") == strings.TrimSuffix(u.String(), "/") {
+			return &arm.ClientOptions{ClientOptions: policy.ClientOptions{Cloud: c}},
+				&azidentity.DefaultAzureCredentialOptions{ClientOptions: policy.ClientOptions{Cloud: c}}, nil
+		}
+	}
+
+	// an unknown endpoint is its own audience and the credential uses the default (or AZURE_AUTHORITY_HOST) authority
+	custom := cloud.Configuration{
+		Services: map[cloud.ServiceName]cloud.ServiceConfiguration{
+			cloud.ResourceManager: {Endpoint: u.String(), Audience: u.String()},
+		},
+	}
+
+	return &arm.ClientOptions{ClientOptions: policy.ClientOptions{Cloud: custom, InsecureAllowCredentialWithHTTP: u.Scheme == "http"}}, nil, nil
+}
+
+func supportedResourceTypes() string {
+	var quoted []string
+
+	for _, k := range sortedKeys(nameAvailabilityChecks) {
+		quoted = append(quoted, "`"+k+"`")
+	}
+
+	return strings.Join(quoted[:len(quoted)-1], ", ") + " and " + quoted[len(quoted)-1]
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
@@ -0,0 +1,136 @@
+package datasource_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"terraform-provider-namep/internal/acctest"
+	namep "terraform-provider-namep/internal/datasource"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+type fakeCredential struct{}
+
+func (fakeCredential) GetToken(context.Context, policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	return azcore.AccessToken{Token: "fake", ExpiresOn: time.Now().Add(time.Hour)}, nil
+}
+
+// fakeArmServer serves the subscriptions list and the checkNameAvailability operations, where only the names in taken are not available.
+func fakeArmServer(t *testing.T, taken map[string]bool) *httptest.Server {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /subscriptions", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"value": [
+			{"id": "/subscriptions/00000000-0000-0000-0000-000000000001", "subscriptionId": "00000000-0000-0000-0000-000000000001", "displayName": "dev"},
+			{"id": "/subscriptions/00000000-0000-0000-0000-000000000002", "subscriptionId": "00000000-0000-0000-0000-000000000002", "displayName": "prd"}
+		]}`)
+	})
+
+	mux.HandleFunc("POST /subscriptions/{subscription}/providers/{namespace}/checkNameAvailability", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Name string `json:"name"`
+			Type string `json:"type"`
+		}
+
+		if r.Header.Get("Authorization") != "Bearer fake" || r.URL.Query().Get("api-version") == "" || json.NewDecoder(r.Body).Decode(&body) != nil {
+			http.Error(w, `{"error": {"code": "BadRequest", "message": "bad request"}}`, http.StatusBadRequest)
+			return
+		}
+
+		key := r.PathValue("subscription") + "/" + body.Type + "/" + body.Name
+
+		if taken[key] {
+			fmt.Fprintf(w, `{"nameAvailable": false, "reason": "AlreadyExists", "message": "The name %s is already taken."}`, body.Name)
+		} else {
+			fmt.Fprint(w, `{"nameAvailable": true}`)
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestAccDataSourceAzureNameAvailability_available(t *testing.T) {
+	namep.SetAzureCredential(t, fakeCredential{})
+	server := fakeArmServer(t, map[string]bool{
+		"00000000-0000-0000-0000-000000000002/Microsoft.Storage/storageAccounts/stmyappprd": true,
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`data "namep_azure_name_availability" "free" {
+					resource_type   = "azurerm_storage_account"
+					name            = "stmyappdev"
+					subscription_id = "00000000-0000-0000-0000-000000000002"
+					endpoint        = %[1]q
+				}
+
+				data "namep_azure_name_availability" "taken" {
+					resource_type             = "azurerm_storage_account"
+					name                      = "stmyappprd"
+					subscription_display_name = "prd"
+					endpoint                  = %[1]q
+				}`, server.URL),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("data.namep_azure_name_availability.free", tfjsonpath.New("available"), knownvalue.Bool(true)),
+					statecheck.ExpectKnownValue("data.namep_azure_name_availability.free", tfjsonpath.New("reason"), knownvalue.StringExact("")),
+					statecheck.ExpectKnownValue("data.namep_azure_name_availability.taken", tfjsonpath.New("available"), knownvalue.Bool(false)),
+					statecheck.ExpectKnownValue("data.namep_azure_name_availability.taken", tfjsonpath.New("reason"), knownvalue.StringExact("AlreadyExists")),
+					statecheck.ExpectKnownValue("data.namep_azure_name_availability.taken", tfjsonpath.New("message"), knownvalue.StringExact("The name stmyappprd is already taken.")),
+				},
+			},
+		},
+	})
+}
+
+func TestAccDataSourceAzureNameAvailability_errors(t *testing.T) {
+	namep.SetAzureCredential(t, fakeCredential{})
+	server := fakeArmServer(t, nil)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "namep_azure_name_availability" "example" {
+					resource_type   = "azurerm_resource_group"
+					name            = "rg-myapp"
+					subscription_id = "00000000-0000-0000-0000-000000000001"
+				}`,
+				ExpectError: regexp.MustCompile(`value\s+must\s+be\s+one\s+of`),
+			},
+			{
+				Config: `data "namep_azure_name_availability" "example" {
+					resource_type   = "azurerm_key_vault"
+					name            = "kv-myapp"
+					subscription_id = "00000000-0000-0000-0000-000000000001"
+					endpoint        = "http://management.example.com"
+				}`,
+				ExpectError: regexp.MustCompile(`must\s+use\s+https`),
+			},
+			{
+				Config: fmt.Sprintf(`data "namep_azure_name_availability" "example" {
+					resource_type             = "azurerm_key_vault"
+					name                      = "kv-myapp"
+					subscription_display_name = "missing"
+					endpoint                  = %q
+				}`, server.URL),
+				ExpectError: regexp.MustCompile(`subscription\s+missing\s+not\s+found`),
+			},
+		},
+	})
+}
@@ -2,18 +2,25 @@ package datasource
 
 import (
 	"context"
+	"fmt"
+	"maps"
+
+	namepf "terraform-provider-namep/internal/functions"
+	"terraform-provider-namep/internal/shared"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ datasource.DataSource = &configurationDataSource{}
+	_ datasource.DataSource              = &configurationDataSource{}
+	_ datasource.DataSourceWithConfigure = &configurationDataSource{}
 )
 
 // New is a helper function to simplify the provider implementation.
@@ -23,14 +30,17 @@ func NewConfiguration() datasource.DataSource {
 
 // data source implementation.
 type configurationDataSource struct {
+	defaults types.Object
 }
 
 type configurationDataSourceModel struct {
-	Formats       types.Map    `tfsdk:"formats"`
-	Variables     types.Map    `tfsdk:"variables"`
-	VariableMaps  types.Map    `tfsdk:"variable_maps"`
-	Types         types.Map    `tfsdk:"types"`
-	Configuration types.Object `tfsdk:"configuration"`
+	Formats             types.Map    `tfsdk:"formats"`
+	Variables           types.Map    `tfsdk:"variables"`
+	VariableMaps        types.Map    `tfsdk:"variable_maps"`
+	Types               types.Map    `tfsdk:"types"`
+	Validate            types.Bool   `tfsdk:"validate"`
+	ValidationVariables types.Map    `tfsdk:"validation_variables"`
+	Configuration       types.Object `tfsdk:"configuration"`
 }
 
 type configurationModel struct {
@@ -47,7 +57,10 @@ func (d *configurationDataSource) Metadata(_ context.Context, req datasource.Met
 func (d *configurationDataSource) Schema(ctx context.Context, ds datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: `This data resource provides a suitable value to be used in the ` + "`configuration` parameter of the `namestring` function" + `.  The produced configuration is always guaranteed to
-		have the right shape for the function. For an detailed explanation of these fields, see the [namestring function documentation](../functions/namestring.md).`,
+		have the right shape for the function. For an detailed explanation of these fields, see the [namestring function documentation](../functions/namestring.md).
+
+If the provider has a default configuration (see the provider ` + "`formats`, `variables`, `variable_maps`, `types_sources` and `types_file`" + ` arguments), its values are
+used as the base: the entries of ` + "`formats`, `variables`, `variable_maps` and `types`" + ` are added to (or replace) the entries of the default configuration.  The names of variables and variable maps are compared ignoring case.`,
 		Attributes: map[string]schema.Attribute{
 			"formats": schema.MapAttribute{
 				Description: "Map of formats.",
@@ -75,6 +88,16 @@ func (d *configurationDataSource) Schema(ctx context.Context, ds datasource.Sche
 				Optional:    true,
 				ElementType: typesAttributes(),
 			},
+			"validate": schema.BoolAttribute{
+				Description: "If true, the format of every type is rendered with the variables and every name which cannot be generated or does not pass the validation of its type " +
+					"is reported as an error.  Types without a format and variables which are not used by any format are reported as warnings.",
+				Optional: true,
+			},
+			"validation_variables": schema.MapAttribute{
+				Description: "Variables which override `variables` when validating, e.g. sample values for variables which are set by the `overrides` argument of the `namestring` function.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
 			"configuration": schema.ObjectAttribute{
 				Description:    "The configuration produced from the inputs.  This can be passed directly to the `namestring` function in the `configuration` parameter.",
 				Computed:       true,
@@ -84,11 +107,37 @@ func (d *configurationDataSource) Schema(ctx context.Context, ds datasource.Sche
 	}
 }
 
+func (d *configurationDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(shared.NamepConfig)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *NamepConfig, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.defaults = config.Configuration
+}
+
 func (d *configurationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var config configurationDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
 
+	if !d.defaults.IsNull() {
+		resp.Diagnostics.Append(config.withDefaults(ctx, d.defaults)...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	formats, diag := tomap(ctx, config.Formats)
 	if diag.HasError() {
 		resp.Diagnostics.Append(diag.Errors()...)
@@ -101,6 +150,8 @@ func (d *configurationDataSource) Read(ctx context.Context, req datasource.ReadR
 	}
 	config.Variables = variables
 
+	config.Types = withCleanupRegex(config.Types)
+
 	if config.Types.IsNull() {
 		configTypes := make(map[string](map[string]string))
 		ct, diag := types.MapValueFrom(ctx, typesAttributes(), configTypes)
@@ -136,6 +187,16 @@ func (d *configurationDataSource) Read(ctx context.Context, req datasource.ReadR
 	}
 	config.Configuration = c
 
+	if config.Validate.ValueBool() && !c.IsUnknown() {
+		samples := make(map[string]string)
+
+		if !config.ValidationVariables.IsNull() {
+			resp.Diagnostics.Append(config.ValidationVariables.ElementsAs(ctx, &samples, false)...)
+		}
+
+		resp.Diagnostics.Append(namepf.ValidateConfiguration(ctx, c, samples)...)
+	}
+
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
 	tflog.Trace(ctx, "read configuration data source")
@@ -144,6 +205,75 @@ func (d *configurationDataSource) Read(ctx context.Context, req datasource.ReadR
 	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
 }
 
+// withDefaults uses the maps of the default configuration as the base of the maps of the data source.
+func (m *configurationDataSourceModel) withDefaults(ctx context.Context, defaults types.Object) diag.Diagnostics {
+	var base configurationModel
+
+	if defaults.IsUnknown() {
+		var diags diag.Diagnostics
+		diags.AddError("Default configuration not known",
+			"The default configuration of the provider uses values which are not known until apply (e.g. attributes of resources).  Data sources are read "+
+				"while planning, so the provider configuration must only use values which are known at plan time.")
+		return diags
+	}
+
+	if diags := defaults.As(ctx, &base, basetypes.ObjectAsOptions{}); diags.HasError() {
+		return diags
+	}
+
+	m.Formats = mergeMaps(base.Formats, m.Formats, false)
+	m.Variables = mergeMaps(base.Variables, m.Variables, true)
+	m.VariableMaps = mergeMaps(base.VariableMaps, m.VariableMaps, true)
+	m.Types = mergeMaps(base.Types, m.Types, false)
+
+	return nil
+}
+
+// mergeMaps returns the entries of base with the entries of m added or replaced.  If foldCase is set, names which only differ in case are
+// the same entry (variables and variable maps are looked up by their uppercased name).  The result is unknown if either map is unknown.
+func mergeMaps(base types.Map, m types.Map, foldCase bool) types.Map {
+	if m.IsNull() {
+		return base
+	}
+
+	if base.IsUnknown() || m.IsUnknown() {
+		return types.MapUnknown(m.ElementType(context.Background()))
+	}
+
+	elements := base.Elements()
+
+	if foldCase {
+		mergeFold(elements, m.Elements())
+	} else {
+		maps.Copy(elements, m.Elements())
+	}
+
+	return types.MapValueMust(m.ElementType(context.Background()), elements)
+}
+
+// withCleanupRegex sets a null cleanup_regex of the types to the empty string, so types which do not set it are not sanitized.
+func withCleanupRegex(typesMap types.Map) types.Map {
+	if typesMap.IsNull() || typesMap.IsUnknown() {
+		return typesMap
+	}
+
+	elements := typesMap.Elements()
+
+	for k, v := range elements {
+		o, ok := v.(types.Object)
+
+		if !ok || o.IsNull() || o.IsUnknown() || !o.Attributes()["cleanup_regex"].IsNull() {
+			continue
+		}
+
+		attributes := o.Attributes()
+		attributes["cleanup_regex"] = types.StringValue("")
+		elements[k] = types.ObjectValueMust(typesAttributes().AttrTypes, attributes)
+	}
+
+	return types.MapValueMust(typesAttributes(), elements)
+}
+
 func configAttributes() map[string]attr.Type {
 	return map[string]attr.Type{
 		"formats": types.MapType{
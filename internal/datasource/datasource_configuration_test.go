@@ -1,6 +1,8 @@
 package datasource_test
 
 import (
+	"fmt"
+	"regexp"
 	"terraform-provider-namep/internal/acctest"
 	"testing"
 
@@ -143,3 +145,223 @@ func TestAccDataSourceConfiguration_types(t *testing.T) {
 		},
 	})
 }
+
+func TestAccDataSourceConfiguration_types_null_cleanup_regex(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "namep_configuration" "example" {
+				  types = {
+				    my_type = {
+				      name             = "my_type"
+				      slug             = "mt"
+				      min_length       = 1
+				      max_length       = 10
+				      lowercase        = true
+				      validation_regex = "^[a-z-]+$"
+				      cleanup_regex    = null
+				      default_selector = "custom"
+				    }
+				  }
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.namep_configuration.example",
+						tfjsonpath.New("configuration").AtMapKey("types").AtMapKey("my_type").AtMapKey("cleanup_regex"),
+						knownvalue.StringExact(""),
+					),
+				},
+			},
+		},
+	})
+}
+
+func TestAccDataSourceConfiguration_validate(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "namep_azure_caf_types" "example" {}
+
+				data "namep_configuration" "example" {
+				  types    = { azurerm_resource_group = data.namep_azure_caf_types.example.types["azurerm_resource_group"] }
+				  validate = true
+				  formats = {
+				    azure_dashes = "#{SLUG}-#{APP}-#{NAME}"
+				  }
+				  variables = {
+				    app = "myapp"
+				  }
+				  validation_variables = {
+				    name = "main"
+				  }
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.namep_configuration.example",
+						tfjsonpath.New("validate"),
+						knownvalue.Bool(true),
+					),
+				},
+			},
+			{
+				Config: `data "namep_azure_caf_types" "example" {}
+
+				data "namep_configuration" "example" {
+				  types    = { azurerm_resource_group = data.namep_azure_caf_types.example.types["azurerm_resource_group"] }
+				  validate = true
+				  formats = {
+				    azure_dashes = "#{SLUG}-#{APP}-#{NAME}"
+				  }
+				  variables = {
+				    app  = "myapp"
+				    name = "NOT SET"
+				  }
+				}`,
+				ExpectError: regexp.MustCompile(`Invalid\s+name\s+for\s+type\s+"azurerm_resource_group"`),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceConfiguration_provider_defaults(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `provider "namep" {
+				  formats = {
+				    k8s_label = "#{APP}-#{ENV}-#{NAME}"
+				  }
+				  variables = {
+				    app = "myapp"
+				    env = "dev"
+				  }
+				  types_sources = ["kubernetes"]
+				}
+
+				data "namep_configuration" "example" {
+				  variables = {
+				    env = "prd"
+				  }
+				}
+
+				output "name" {
+				  value = provider::namep::namestring("kubernetes_namespace", data.namep_configuration.example.configuration, { name = "main" })
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.namep_configuration.example",
+						tfjsonpath.New("configuration"),
+						knownvalue.ObjectPartial(map[string]knownvalue.Check{
+							"formats": knownvalue.MapExact(map[string]knownvalue.Check{
+								"k8s_label": knownvalue.StringExact("#{APP}-#{ENV}-#{NAME}"),
+							}),
+							"variables": knownvalue.MapExact(map[string]knownvalue.Check{
+								"app": knownvalue.StringExact("myapp"),
+								"env": knownvalue.StringExact("prd"),
+							}),
+							"types": knownvalue.MapPartial(map[string]knownvalue.Check{
+								"kubernetes_namespace": knownvalue.ObjectPartial(map[string]knownvalue.Check{
+									"default_selector": knownvalue.StringExact("k8s_label"),
+								}),
+							}),
+						}),
+					),
+					statecheck.ExpectKnownOutputValue("name", knownvalue.StringExact("myapp-prd-main")),
+				},
+			},
+			{
+				Config: `resource "terraform_data" "app" {
+				  input = "myapp"
+				}
+
+				provider "namep" {
+				  variables = {
+				    app = terraform_data.app.output
+				  }
+				}
+
+				data "namep_configuration" "example" {}`,
+				ExpectError: regexp.MustCompile(`Default\s+configuration\s+not\s+known`),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceConfiguration_provider_variable_case(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `provider "namep" {
+				  formats = {
+				    custom = "#{APP}-#{ENV}"
+				  }
+				  variables = {
+				    app = "myapp"
+				    env = "dev"
+				  }
+				}
+
+				data "namep_configuration" "example" {
+				  variables = {
+				    APP = "other"
+				  }
+				}
+
+				output "name" {
+				  value = provider::namep::namestring("anything", data.namep_configuration.example.configuration)
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.namep_configuration.example",
+						tfjsonpath.New("configuration").AtMapKey("variables"),
+						knownvalue.MapExact(map[string]knownvalue.Check{
+							"APP": knownvalue.StringExact("other"),
+							"env": knownvalue.StringExact("dev"),
+						}),
+					),
+					statecheck.ExpectKnownOutputValue("name", knownvalue.StringExact("other-dev")),
+				},
+			},
+		},
+	})
+}
+
+func TestAccDataSourceConfiguration_provider_types_file(t *testing.T) {
+	file := writeTestFile(t, "types.yaml", `kubernetes_namespace:
+  slug: nsp
+  default_selector: k8s_label
+`)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`provider "namep" {
+				  types_sources = ["kubernetes"]
+				  types_file    = %q
+				}
+
+				data "namep_configuration" "example" {}`, file),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.namep_configuration.example",
+						tfjsonpath.New("configuration").AtMapKey("types").AtMapKey("kubernetes_namespace").AtMapKey("slug"),
+						knownvalue.StringExact("nsp"),
+					),
+				},
+			},
+			{
+				Config: `provider "namep" {
+				  types_sources = ["azure"]
+				}
+
+				data "namep_configuration" "example" {}`,
+				ExpectError: regexp.MustCompile(`value\s+must\s+be\s+one\s+of`),
+			},
+		},
+	})
+}
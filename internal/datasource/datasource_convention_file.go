@@ -0,0 +1,309 @@
+package datasource
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	namepf "terraform-provider-namep/internal/functions"
+	"terraform-provider-namep/internal/shared"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"gopkg.in/yaml.v3"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource = &conventionFileDataSource{}
+)
+
+// New is a helper function to simplify the provider implementation.
+func NewConventionFile() datasource.DataSource {
+	return &conventionFileDataSource{}
+}
+
+// data source implementation.
+type conventionFileDataSource struct{}
+
+type conventionFileDataSourceModel struct {
+	Path                types.String `tfsdk:"path"`
+	Variables           types.Map    `tfsdk:"variables"`
+	VariableMaps        types.Map    `tfsdk:"variable_maps"`
+	Types               types.Map    `tfsdk:"types"`
+	Validate            types.Bool   `tfsdk:"validate"`
+	ValidationVariables types.Map    `tfsdk:"validation_variables"`
+	Configuration       types.Object `tfsdk:"configuration"`
+}
+
+// convention is the content of a convention file.
+type convention struct {
+	formats      map[string]string
+	variables    map[string]string
+	variableMaps map[string]map[string]string
+	types        map[string]shared.TypeFields
+}
+
+func (d *conventionFileDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_convention_file"
+}
+
+func (d *conventionFileDataSource) Schema(ctx context.Context, ds datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `This data resource loads a complete naming convention from a YAML or JSON file and provides the same ` + "`configuration`" + ` as the [namep_configuration](configuration.md)
+data source.  This allows the convention to be kept in a shared repository, reviewed as data and used by tools other than Terraform.  The file is a map with the
+optional keys ` + "`formats`" + `, ` + "`variables`" + `, ` + "`variable_maps`" + ` and ` + "`types`" + `:
+
+` + "```yaml" + `
+formats:
+  azure_dashes: "#{SLUG}-#{APP}-#{ENV}-#{LOCS[LOC]}-#{NAME}"
+  azure_nodashes: "#{SLUG}#{APP}#{ENV}#{LOCS[LOC]}#{NAME}"
+  vault: "#{APP}/#{ENV}/#{NAME}"
+variables:
+  app: myapp
+variable_maps:
+  envs:
+    development: dev
+    production: prd
+types:
+  vault_path:
+    slug: vp
+    max_length: 128
+    validation_regex: "^[a-z0-9/_-]{1,128}$"
+    default_selector: vault
+` + "```" + `
+
+The entries of ` + "`types`" + ` have the same fields as the types of the [namep_types_file](types_file.md) data source.  The file is validated when it is loaded and
+every problem is reported with the line number in the file.
+
+The ` + "`types`" + ` and ` + "`variable_maps`" + ` attributes of this data source are the base the file extends, so that types and maps from other data sources (e.g. ` + "`namep_azure_caf_types`" + `)
+can be used with the convention: entries of the file replace the entries with the same name (the names of variable maps are compared ignoring case).  The
+` + "`variables`" + ` attribute is for the variables of the root module (e.g. the environment) and replaces the variables of the file with the same name (names are
+compared ignoring case).`,
+		Attributes: map[string]schema.Attribute{
+			"path": schema.StringAttribute{
+				Description: "Path of the YAML or JSON file to load the convention from (relative paths are relative to the working directory of Terraform, use `path.module` for files in a module).",
+				Required:    true,
+			},
+			"variables": schema.MapAttribute{
+				Description: "Map of variables which replace the variables of the file.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"variable_maps": schema.MapAttribute{
+				Description: `Map of maps of variables, which the variable maps of the file are added to.  Most commonly created by a "locations" data source.`,
+				Optional:    true,
+				ElementType: types.MapType{
+					ElemType: types.StringType,
+				},
+			},
+			"types": schema.MapAttribute{
+				Description: `A map of types which the types of the file are added to, usually created by one of the "types" data sources.`,
+				Optional:    true,
+				ElementType: typesAttributes(),
+			},
+			"validate": schema.BoolAttribute{
+				Description: "If true, the configuration is validated the same way as by the `validate` field of the `namep_configuration` data source.",
+				Optional:    true,
+			},
+			"validation_variables": schema.MapAttribute{
+				Description: "Variables which override `variables` when validating, e.g. sample values for variables which are set by the `overrides` argument of the `namestring` function.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"configuration": schema.ObjectAttribute{
+				Description:    "The configuration produced from the file and the inputs.  This can be passed directly to the `namestring` function in the `configuration` parameter.",
+				Computed:       true,
+				AttributeTypes: configAttributes(),
+			},
+		},
+	}
+}
+
+func (d *conventionFileDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config conventionFileDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filename := config.Path.ValueString()
+	content, err := os.ReadFile(filename)
+
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("path"), "Failed to read convention file", err.Error())
+		return
+	}
+
+	conv, errs := loadConvention(filename, content)
+
+	for _, err := range errs {
+		resp.Diagnostics.AddAttributeError(path.Root("path"), "Invalid convention file", err)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Variables.IsNull() {
+		variables := make(map[string]string)
+		resp.Diagnostics.Append(config.Variables.ElementsAs(ctx, &variables, false)...)
+
+		mergeFold(conv.variables, variables)
+	}
+
+	if !config.VariableMaps.IsNull() {
+		variableMaps := make(map[string]map[string]string)
+		resp.Diagnostics.Append(config.VariableMaps.ElementsAs(ctx, &variableMaps, false)...)
+
+		mergeFold(variableMaps, conv.variableMaps)
+		conv.variableMaps = variableMaps
+	}
+
+	if !config.Types.IsNull() {
+		typeInfoMap := make(map[string]shared.TypeFields)
+		resp.Diagnostics.Append(withCleanupRegex(config.Types).ElementsAs(ctx, &typeInfoMap, false)...)
+
+		for k, v := range conv.types {
+			typeInfoMap[k] = v
+		}
+		conv.types = typeInfoMap
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	formats, diag := types.MapValueFrom(ctx, types.StringType, conv.formats)
+	resp.Diagnostics.Append(diag...)
+	variables, diag := types.MapValueFrom(ctx, types.StringType, conv.variables)
+	resp.Diagnostics.Append(diag...)
+	variableMaps, diag := types.MapValueFrom(ctx, types.MapType{ElemType: types.StringType}, conv.variableMaps)
+	resp.Diagnostics.Append(diag...)
+	typeInfoMap, diag := types.MapValueFrom(ctx, typesAttributes(), conv.types)
+	resp.Diagnostics.Append(diag...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	c, diag := types.ObjectValueFrom(ctx, configAttributes(), configurationModel{
+		Formats:      formats,
+		Variables:    variables,
+		VariableMaps: variableMaps,
+		Types:        typeInfoMap,
+	})
+	resp.Diagnostics.Append(diag...)
+	config.Configuration = c
+
+	if config.Validate.ValueBool() && !resp.Diagnostics.HasError() {
+		samples := make(map[string]string)
+
+		if !config.ValidationVariables.IsNull() {
+			resp.Diagnostics.Append(config.ValidationVariables.ElementsAs(ctx, &samples, false)...)
+		}
+
+		resp.Diagnostics.Append(namepf.ValidateConfiguration(ctx, c, samples)...)
+	}
+
+	tflog.Trace(ctx, "read convention file data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+// loadConvention parses a convention file.  Every problem found is returned, prefixed with the file name and line number.
+func loadConvention(filename string, content []byte) (*convention, []string) {
+	errs := &fileErrors{filename: filename}
+	conv := &convention{
+		formats:      make(map[string]string),
+		variables:    make(map[string]string),
+		variableMaps: make(map[string]map[string]string),
+		types:        make(map[string]shared.TypeFields),
+	}
+	root := parseYAML(filename, content, errs)
+
+	if root == nil {
+		return conv, errs.errs
+	}
+
+	if root.Kind != yaml.MappingNode {
+		errs.at(root, "expected a map with the keys formats, variables, variable_maps and types")
+		return conv, errs.errs
+	}
+
+	seen := make(map[string]bool)
+
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		keyNode, valueNode := root.Content[i], root.Content[i+1]
+
+		if seen[keyNode.Value] {
+			errs.at(keyNode, "%q is defined more than once", keyNode.Value)
+			continue
+		}
+		seen[keyNode.Value] = true
+
+		switch keyNode.Value {
+		case "formats":
+			conv.formats = decodeStringMap(valueNode, "formats", "format", errs)
+		case "variables":
+			conv.variables = decodeStringMap(valueNode, "variables", "variable", errs)
+		case "variable_maps":
+			if valueNode.Kind != yaml.MappingNode {
+				errs.at(valueNode, "variable_maps must be a map of maps")
+				continue
+			}
+
+			for j := 0; j+1 < len(valueNode.Content); j += 2 {
+				mapName := valueNode.Content[j].Value
+
+				if _, exists := conv.variableMaps[mapName]; exists {
+					errs.at(valueNode.Content[j], "variable map %q is defined more than once", mapName)
+					continue
+				}
+
+				conv.variableMaps[mapName] = decodeStringMap(valueNode.Content[j+1], fmt.Sprintf("variable map %q", mapName), "entry", errs)
+			}
+		case "types":
+			conv.types = decodeTypes(valueNode, errs)
+		default:
+			errs.at(keyNode, "unknown key %q, expected formats, variables, variable_maps or types", keyNode.Value)
+		}
+	}
+
+	return conv, errs.errs
+}
+
+// decodeStringMap decodes a map node whose values must all be scalars.  The name of the map and the kind of its entries are used in the
+// errors.
+func decodeStringMap(node *yaml.Node, name string, kind string, errs *fileErrors) map[string]string {
+	result := make(map[string]string)
+
+	if node.Kind != yaml.MappingNode {
+		errs.at(node, "%s must be a map of strings", name)
+		return result
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valueNode := node.Content[i], node.Content[i+1]
+
+		if _, exists := result[keyNode.Value]; exists {
+			errs.at(keyNode, "%s %q is defined more than once in %s", kind, keyNode.Value, name)
+			continue
+		}
+
+		if valueNode.Kind != yaml.ScalarNode {
+			errs.at(valueNode, "%s %q in %s must be a string", kind, keyNode.Value, name)
+			continue
+		}
+
+		result[keyNode.Value] = valueNode.Value
+	}
+
+	return result
+}
@@ -0,0 +1,172 @@
+package datasource_test
+
+import (
+	"fmt"
+	"regexp"
+	"terraform-provider-namep/internal/acctest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccDataSourceConventionFile_read(t *testing.T) {
+	filename := writeTestFile(t, "convention.yaml", `
+formats:
+  azure_dashes: "#{SLUG}-#{APP}-#{ENVS[ENV]}-#{NAME}"
+  vault: "#{APP}/#{ENV}/#{NAME}"
+variables:
+  app: myapp
+  env: development
+variable_maps:
+  envs:
+    development: dev
+    production: prd
+types:
+  vault_path:
+    slug: vp
+    default_selector: vault
+`)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`data "namep_azure_caf_types" "example" {
+					static = true
+				}
+
+				data "namep_convention_file" "example" {
+				  path  = %q
+				  types = data.namep_azure_caf_types.example.types
+				  variables = {
+				    env = "production"
+				  }
+				}
+
+				output "resource_group" {
+				  value = provider::namep::namestring("azurerm_resource_group", data.namep_convention_file.example.configuration, { name = "main" })
+				}
+
+				output "vault_path" {
+				  value = provider::namep::namestring("vault_path", data.namep_convention_file.example.configuration, { name = "secrets" })
+				}`, filename),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("resource_group", knownvalue.StringExact("rg-myapp-prd-main")),
+					statecheck.ExpectKnownOutputValue("vault_path", knownvalue.StringExact("myapp/production/secrets")),
+				},
+			},
+		},
+	})
+}
+
+func TestAccDataSourceConventionFile_variable_case(t *testing.T) {
+	filename := writeTestFile(t, "convention.yaml", `
+formats:
+  custom: "#{APP}-#{ENV}"
+variables:
+  app: myapp
+  env: dev
+`)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`data "namep_convention_file" "example" {
+				  path = %q
+				  variables = {
+				    APP = "other"
+				  }
+				}
+
+				output "name" {
+				  value = provider::namep::namestring("anything", data.namep_convention_file.example.configuration)
+				}`, filename),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.namep_convention_file.example",
+						tfjsonpath.New("configuration").AtMapKey("variables"),
+						knownvalue.MapExact(map[string]knownvalue.Check{
+							"APP": knownvalue.StringExact("other"),
+							"env": knownvalue.StringExact("dev"),
+						}),
+					),
+					statecheck.ExpectKnownOutputValue("name", knownvalue.StringExact("other-dev")),
+				},
+			},
+		},
+	})
+}
+
+func TestAccDataSourceConventionFile_variable_map_case(t *testing.T) {
+	filename := writeTestFile(t, "convention.yaml", `
+formats:
+  custom: "#{APP}-#{ENVS[ENV]}"
+variables:
+  app: myapp
+  env: development
+variable_maps:
+  envs:
+    development: dev
+`)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`data "namep_convention_file" "example" {
+				  path = %q
+				  variable_maps = {
+				    ENVS = {
+				      development = "other"
+				    }
+				    locs = {
+				      westeurope = "we"
+				    }
+				  }
+				}
+
+				output "name" {
+				  value = provider::namep::namestring("anything", data.namep_convention_file.example.configuration)
+				}`, filename),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.namep_convention_file.example",
+						tfjsonpath.New("configuration").AtMapKey("variable_maps"),
+						knownvalue.MapExact(map[string]knownvalue.Check{
+							"envs": knownvalue.MapExact(map[string]knownvalue.Check{
+								"development": knownvalue.StringExact("dev"),
+							}),
+							"locs": knownvalue.MapExact(map[string]knownvalue.Check{
+								"westeurope": knownvalue.StringExact("we"),
+							}),
+						}),
+					),
+					statecheck.ExpectKnownOutputValue("name", knownvalue.StringExact("myapp-dev")),
+				},
+			},
+		},
+	})
+}
+
+func TestAccDataSourceConventionFile_errors(t *testing.T) {
+	filename := writeTestFile(t, "convention.yaml", `formats:
+  azure_dashes: ["#{SLUG}"]
+colours: {}
+`)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`data "namep_convention_file" "example" {
+					path = %q
+				}`, filename),
+				ExpectError: regexp.MustCompile(`(?s)convention\.yaml:2:\s+format\s+"azure_dashes"\s+in\s+formats\s+must\s+be\s+a\s+string.*convention\.yaml:3:\s+unknown\s+key\s+"colours"`),
+			},
+		},
+	})
+}
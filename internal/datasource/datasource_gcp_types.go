@@ -0,0 +1,167 @@
+package datasource
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"terraform-provider-namep/internal/cloud/gcp"
+	"terraform-provider-namep/internal/shared"
+	"terraform-provider-namep/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                     = &gcpTypesDataSource{}
+	_ datasource.DataSourceWithConfigure        = &gcpTypesDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &gcpTypesDataSource{}
+)
+
+// New is a helper function to simplify the provider implementation.
+func NewGcpTypes() datasource.DataSource {
+	return &gcpTypesDataSource{}
+}
+
+// data source implementation.
+type gcpTypesDataSource struct {
+	static bool
+}
+
+type gcpTypesDataSourceModel struct {
+	Url      types.String `tfsdk:"url"`
+	Static   types.Bool   `tfsdk:"static"`
+	Sanitize types.Bool   `tfsdk:"sanitize"`
+	Source   types.String `tfsdk:"source"`
+	Types    types.Map    `tfsdk:"types"`
+}
+
+func (d *gcpTypesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_gcp_types"
+}
+
+func (d *gcpTypesDataSource) Schema(ctx context.Context, ds datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `This data resource creates a map of Google Cloud resource type names to type information.  The types embedded when this provider was built are used, unless the
+` + "`url`" + ` field is set, in which case the types are fetched from it.  If the ` + "`static`" + ` field is true then the embedded types are always used.  Note that if ` + "`static`" + ` is
+set to true in the provider, it will be used regardless of the value in the data source.  There will, however, be no conflict between the provider ` + "`static`" + ` field and the ` + "`url`" + ` field in this datasource (it will be ignored).
+
+The purpose of this data source is for creating the types to to be passed to the ` + "`types`" + ` parameter in the [namep_configuration](configuration.md) data source.  Alternatively, it could be assigned to a ` + "`locals`" + ` variable to 
+add other types for the ` + "`types`" + ` parameter.
+
+## Default Selector
+
+The ` + "`defaultSelector`" + ` for this resource is made up of 3 components: the word "gcp", the word "dashes" or "nodashes" (depending on if dashes are allowed in the name of the resource type), and the ` + "`scope`" + ` of the resource.
+The main ` + "`scope`" + ` to be concerned about is the "global" scope (e.g. storage buckets and project IDs), which means the name must be unique across all of Google Cloud.  The other scopes are "project", "region", "zone" and "parent".
+
+` + sanitizeDescription("the regex matching the characters which are not allowed in the name"),
+		Attributes: map[string]schema.Attribute{
+			"static": schema.BoolAttribute{
+				Description: "Static flag to determine if the data source should use data embedded when this data source was built, even if `url` is set.",
+				Required:    false,
+				Optional:    true,
+			},
+			"url": schema.StringAttribute{
+				Description: "The URL of the GCP types to fetch, in the format of the dataset of this provider.  The embedded types are used if not specified.",
+				Required:    false,
+				Optional:    true,
+			},
+			"sanitize": sanitizeAttribute("cleanup regex"),
+			"source": schema.StringAttribute{
+				Description: "The source URL the GCP types were loaded from.",
+				Computed:    true,
+			},
+			"types": schema.MapAttribute{
+				Description: "The type info map of the GCP resource types.",
+				Computed:    true,
+				ElementType: typesAttributes(),
+			},
+		},
+	}
+}
+
+func (d *gcpTypesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(shared.NamepConfig)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *NamepConfig, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.static = config.Static
+}
+
+func (d *gcpTypesDataSource) ConfigValidators(context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.Conflicting(
+			path.MatchRoot("url"),
+			path.MatchRoot("static"),
+		),
+	}
+}
+
+func (d *gcpTypesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config gcpTypesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	source := "static"
+	defs := gcp.ResourceDefinitions
+	sanitize := config.Sanitize.ValueBool()
+
+	if !d.static && !config.Static.ValueBool() && !config.Url.IsNull() {
+		source = config.Url.ValueString()
+
+		var fetched []gcp.ResourceStructure
+
+		if err := utils.GetJSON(source, &fetched); err != nil {
+			tflog.Error(ctx, fmt.Sprintf("Failed to fetch GCP types (url: %s): %v", source, err))
+			resp.Diagnostics.AddError("Failed to fetch GCP types", err.Error())
+			return
+		}
+
+		defs = make(map[string]gcp.ResourceStructure, len(fetched))
+
+		for _, def := range fetched {
+			for _, regex := range []string{def.RegEx, def.ValidationRegExp} {
+				if _, err := regexp.Compile(regex); err != nil {
+					resp.Diagnostics.AddAttributeError(path.Root("url"), "Invalid GCP types", fmt.Sprintf("invalid regex %q in type %q: %v", regex, def.ResourceTypeName, err))
+				}
+			}
+
+			defs[def.ResourceTypeName] = def
+		}
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	result, diag := types.MapValueFrom(ctx, typesAttributes(), cloudTypes(defs, "gcp", sanitize))
+
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag.Errors()...)
+		return
+	}
+
+	config.Source = types.StringValue(source)
+	config.Types = result
+
+	tflog.Trace(ctx, "read gcp type data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
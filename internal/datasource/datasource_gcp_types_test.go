@@ -0,0 +1,160 @@
+package datasource_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"terraform-provider-namep/internal/acctest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccDataSourceGcpTypes_static(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "namep_gcp_types" "example" {
+					static = true
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.namep_gcp_types.example",
+						tfjsonpath.New("types"),
+						knownvalue.MapPartial(map[string]knownvalue.Check{
+							"google_project": knownvalue.ObjectPartial(map[string]knownvalue.Check{
+								"name":             knownvalue.StringExact("google_project"),
+								"slug":             knownvalue.StringExact("prj"),
+								"min_length":       knownvalue.Int64Exact(6),
+								"max_length":       knownvalue.Int64Exact(30),
+								"lowercase":        knownvalue.Bool(true),
+								"default_selector": knownvalue.StringExact("gcp_dashes_global"),
+							}),
+							"google_compute_instance": knownvalue.ObjectPartial(map[string]knownvalue.Check{
+								"validation_regex": knownvalue.StringExact("^[a-z]([a-z0-9-]{0,61}[a-z0-9])?$"),
+								"default_selector": knownvalue.StringExact("gcp_dashes_zone"),
+							}),
+						}),
+					),
+					statecheck.ExpectKnownValue(
+						"data.namep_gcp_types.example",
+						tfjsonpath.New("source"),
+						knownvalue.StringExact("static"),
+					),
+				},
+			},
+		},
+	})
+}
+
+func TestAccDataSourceGcpTypes_default(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "namep_gcp_types" "example" {}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.namep_gcp_types.example",
+						tfjsonpath.New("types").AtMapKey("google_project").AtMapKey("slug"),
+						knownvalue.StringExact("prj"),
+					),
+					statecheck.ExpectKnownValue(
+						"data.namep_gcp_types.example",
+						tfjsonpath.New("source"),
+						knownvalue.StringExact("static"),
+					),
+				},
+			},
+		},
+	})
+}
+
+func TestAccDataSourceGcpTypes_static_provider(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `provider "namep" { static = true }
+				data "namep_gcp_types" "example" {
+					sanitize = true
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.namep_gcp_types.example",
+						tfjsonpath.New("types"),
+						knownvalue.MapPartial(map[string]knownvalue.Check{
+							"google_storage_bucket": knownvalue.ObjectPartial(map[string]knownvalue.Check{
+								"cleanup_regex": knownvalue.StringExact("[^a-z0-9._-]"),
+							}),
+						}),
+					),
+					statecheck.ExpectKnownValue(
+						"data.namep_gcp_types.example",
+						tfjsonpath.New("source"),
+						knownvalue.StringExact("static"),
+					),
+				},
+			},
+		},
+	})
+}
+
+func TestAccDataSourceGcpTypes_url(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"name": "google_thing", "slug": "th", "min_length": 1, "max_length": 10, "validation_regex": "^[a-z]{1,10}$", "dashes": false, "scope": "project"}]`)
+	}))
+	defer server.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`data "namep_gcp_types" "example" {
+					url = %q
+				}`, server.URL),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.namep_gcp_types.example",
+						tfjsonpath.New("types"),
+						knownvalue.MapExact(map[string]knownvalue.Check{
+							"google_thing": knownvalue.ObjectPartial(map[string]knownvalue.Check{
+								"slug":             knownvalue.StringExact("th"),
+								"default_selector": knownvalue.StringExact("gcp_nodashes_project"),
+							}),
+						}),
+					),
+					statecheck.ExpectKnownValue(
+						"data.namep_gcp_types.example",
+						tfjsonpath.New("source"),
+						knownvalue.StringExact(server.URL),
+					),
+				},
+			},
+		},
+	})
+}
+
+func TestAccDataSourceGcpTypes_url_invalid_regex(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"name": "google_thing", "slug": "th", "min_length": 1, "max_length": 10, "regex": "[^a-z", "validation_regex": "^[a-z]{1,10}$", "dashes": false, "scope": "project"}]`)
+	}))
+	defer server.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`data "namep_gcp_types" "example" {
+					url = %q
+				}`, server.URL),
+				ExpectError: regexp.MustCompile(`invalid\s+regex\s+"\[\^a-z"\s+in\s+type\s+"google_thing"`),
+			},
+		},
+	})
+}
@@ -0,0 +1,110 @@
+package datasource
+
+import (
+	"context"
+
+	"terraform-provider-namep/internal/cloud/kubernetes"
+	"terraform-provider-namep/internal/shared"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource = &kubernetesTypesDataSource{}
+)
+
+// New is a helper function to simplify the provider implementation.
+func NewKubernetesTypes() datasource.DataSource {
+	return &kubernetesTypesDataSource{}
+}
+
+// data source implementation.
+type kubernetesTypesDataSource struct{}
+
+type kubernetesTypesDataSourceModel struct {
+	Sanitize types.Bool `tfsdk:"sanitize"`
+	Types    types.Map  `tfsdk:"types"`
+}
+
+func (d *kubernetesTypesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_kubernetes_types"
+}
+
+func (d *kubernetesTypesDataSource) Schema(ctx context.Context, ds datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `This data resource creates a map of Kubernetes object type names (named after the resources of the kubernetes provider) to type information.  The types are
+built into the provider, since the Kubernetes naming rules are part of the Kubernetes API and rarely change.  The map also contains the ` + "`kubernetes_label_value`" + ` type for
+generating label values.
+
+The purpose of this data source is for creating the types to to be passed to the ` + "`types`" + ` parameter in the [namep_configuration](configuration.md) data source.  Alternatively, it could be assigned to a ` + "`locals`" + ` variable to 
+add other types for the ` + "`types`" + ` parameter, or merged with the types of the cloud data sources so one configuration names both cloud and cluster objects.
+
+## Default Selector
+
+The ` + "`defaultSelector`" + ` for this resource is the rule the name must follow:
+
+* ` + "`k8s_label`" + `: DNS-1123 (or RFC 1035) labels, which are at most 63 characters (e.g. namespaces and services).
+* ` + "`k8s_subdomain`" + `: DNS-1123 subdomains, which are at most 253 characters and may contain dots (e.g. config maps and deployments).  Note that the validation regex cannot limit the length
+of a subdomain, the length is limited by ` + "`max_length`" + ` which is checked separately (or truncated to when the name is sanitized).
+* ` + "`k8s_label_value`" + `: label values, which are at most 63 characters, may be empty and are not required to be lowercase.  As the selector starts with ` + "`k8s_label`" + `, the format of labels is used for label values unless there is a format for ` + "`k8s_label_value`" + `.
+
+` + sanitizeDescription("the regex matching the characters which are not allowed in the name"),
+		Attributes: map[string]schema.Attribute{
+			"sanitize": sanitizeAttribute("cleanup regex"),
+			"types": schema.MapAttribute{
+				Description: "The type info map of the Kubernetes object types.",
+				Computed:    true,
+				ElementType: typesAttributes(),
+			},
+		},
+	}
+}
+
+func (d *kubernetesTypesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config kubernetesTypesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	result, diag := types.MapValueFrom(ctx, typesAttributes(), kubernetesTypes(config.Sanitize.ValueBool()))
+
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag.Errors()...)
+		return
+	}
+
+	config.Types = result
+
+	tflog.Trace(ctx, "read kubernetes type data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+func kubernetesTypes(sanitize bool) map[string]shared.TypeFields {
+	typeInfoMap := make(map[string]shared.TypeFields, len(kubernetes.ResourceDefinitions))
+
+	for _, def := range kubernetes.ResourceDefinitions {
+		cleanupRegex := ""
+
+		if sanitize {
+			cleanupRegex = def.Rule.RegEx
+		}
+
+		typeInfoMap[def.ResourceTypeName] = shared.TypeFields{
+			Name:            def.ResourceTypeName,
+			Slug:            def.Slug,
+			MinLength:       def.Rule.MinLength,
+			MaxLength:       def.Rule.MaxLength,
+			Lowercase:       def.Rule.LowerCase,
+			ValidationRegex: def.Rule.ValidationRegExp,
+			CleanupRegex:    cleanupRegex,
+			DefaultSelector: def.Rule.Selector,
+		}
+	}
+
+	return typeInfoMap
+}
@@ -0,0 +1,131 @@
+package datasource_test
+
+import (
+	"regexp"
+	"terraform-provider-namep/internal/acctest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccDataSourceKubernetesTypes_read(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "namep_kubernetes_types" "example" {}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.namep_kubernetes_types.example",
+						tfjsonpath.New("types"),
+						knownvalue.MapPartial(map[string]knownvalue.Check{
+							"kubernetes_namespace": knownvalue.ObjectPartial(map[string]knownvalue.Check{
+								"slug":             knownvalue.StringExact("ns"),
+								"max_length":       knownvalue.Int64Exact(63),
+								"lowercase":        knownvalue.Bool(true),
+								"default_selector": knownvalue.StringExact("k8s_label"),
+							}),
+							"kubernetes_config_map": knownvalue.ObjectPartial(map[string]knownvalue.Check{
+								"max_length":       knownvalue.Int64Exact(253),
+								"default_selector": knownvalue.StringExact("k8s_subdomain"),
+							}),
+							"kubernetes_label_value": knownvalue.ObjectPartial(map[string]knownvalue.Check{
+								"lowercase":        knownvalue.Bool(false),
+								"default_selector": knownvalue.StringExact("k8s_label_value"),
+							}),
+						}),
+					),
+				},
+			},
+		},
+	})
+}
+
+func TestAccDataSourceKubernetesTypes_namestring(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "namep_kubernetes_types" "example" {
+					sanitize = true
+				}
+
+				data "namep_configuration" "example" {
+				  types = data.namep_kubernetes_types.example.types
+				  formats = {
+				    k8s           = "#{APP}-#{NAME}-#{SLUG}"
+				    k8s_subdomain = "#{NAME}.#{APP}"
+				    k8s_label_value = "#{APP}"
+				  }
+				  variables = {
+				    app = "My_App"
+				  }
+				}
+
+				output "namespace" {
+				  value = provider::namep::namestring("kubernetes_namespace", data.namep_configuration.example.configuration, { name = "web" })
+				}
+
+				output "config_map" {
+				  value = provider::namep::namestring("kubernetes_config_map", data.namep_configuration.example.configuration, { name = "web" })
+				}
+
+				output "label_value" {
+				  value = provider::namep::namestring("kubernetes_label_value", data.namep_configuration.example.configuration, { name = "web" })
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("namespace", knownvalue.StringExact("myapp-web-ns")),
+					statecheck.ExpectKnownOutputValue("config_map", knownvalue.StringExact("web.myapp")),
+					statecheck.ExpectKnownOutputValue("label_value", knownvalue.StringExact("My_App")),
+				},
+			},
+		},
+	})
+}
+
+func TestAccDataSourceKubernetesTypes_subdomain_length(t *testing.T) {
+	config := `data "namep_kubernetes_types" "example" {}
+
+	data "namep_configuration" "example" {
+	  types = data.namep_kubernetes_types.example.types
+	  formats = {
+	    k8s_subdomain = "#{NAME}"
+	  }
+	}
+
+	locals {
+	  longest = substr(join(".", [for i in range(4) : join("", [for j in range(63) : "a"])]), 0, 253)
+	}
+	`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config + `
+				output "longest" {
+				  value = provider::namep::validate_name("kubernetes_config_map", local.longest, data.namep_configuration.example.configuration)
+				}
+
+				output "too_long" {
+				  value = provider::namep::validate_name("kubernetes_config_map", "${local.longest}a", data.namep_configuration.example.configuration)
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValueAtPath("longest", tfjsonpath.New("valid"), knownvalue.Bool(true)),
+					statecheck.ExpectKnownOutputValueAtPath("too_long", tfjsonpath.New("valid"), knownvalue.Bool(false)),
+					statecheck.ExpectKnownOutputValueAtPath("too_long", tfjsonpath.New("length"), knownvalue.Int64Exact(254)),
+				},
+			},
+			{
+				Config: config + `
+				output "too_long" {
+				  value = provider::namep::namestring("kubernetes_config_map", data.namep_configuration.example.configuration, { name = "${local.longest}a" })
+				}`,
+				ExpectError: regexp.MustCompile(`resulting\s+name\s+is\s+too\s+long\s+\(254\s+>\s+253\)`),
+			},
+		},
+	})
+}
@@ -0,0 +1,155 @@
+package datasource
+
+import (
+	"context"
+	"fmt"
+
+	namepf "terraform-provider-namep/internal/functions"
+	"terraform-provider-namep/internal/shared"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &namePreviewDataSource{}
+	_ datasource.DataSourceWithConfigure = &namePreviewDataSource{}
+)
+
+func NewNamePreview() datasource.DataSource {
+	return &namePreviewDataSource{}
+}
+
+// data source implementation.
+type namePreviewDataSource struct {
+	defaults types.Object
+}
+
+type namePreviewDataSourceModel struct {
+	Configuration types.Object `tfsdk:"configuration"`
+	ResourceTypes types.List   `tfsdk:"resource_types"`
+	Overrides     types.Map    `tfsdk:"overrides"`
+	Names         types.Map    `tfsdk:"names"`
+	Errors        types.Map    `tfsdk:"errors"`
+}
+
+func (d *namePreviewDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_name_preview"
+}
+
+func (d *namePreviewDataSource) Schema(ctx context.Context, ds datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `This data source generates the names of many resource types at once, e.g. to publish the names of every resource of an environment as an output or review artifact.
+		The names are generated exactly like the [namestring function](../functions/namestring.md) does, but names which cannot be generated are reported in ` + "`errors`" + ` instead of failing.`,
+		Attributes: map[string]schema.Attribute{
+			"configuration": schema.ObjectAttribute{
+				Description:    "The configuration to use, usually the `configuration` of the `namep_configuration` data source.  Defaults to the default configuration of the provider.",
+				Optional:       true,
+				AttributeTypes: configAttributes(),
+			},
+			"resource_types": schema.ListAttribute{
+				Description: "The resource types to generate names for.  Entries can be globs (e.g. `azurerm_storage_*` or `*`) which are matched against the `types` of the configuration.",
+				Required:    true,
+				ElementType: types.StringType,
+			},
+			"overrides": schema.MapAttribute{
+				Description: "Variable overrides, used for every name.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"names": schema.MapAttribute{
+				Description: "Map of resource type to the generated name.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"errors": schema.MapAttribute{
+				Description: "Map of resource type to the error for every name which could not be generated.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (d *namePreviewDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(shared.NamepConfig)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *NamepConfig, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.defaults = config.Configuration
+}
+
+func (d *namePreviewDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config namePreviewDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var patterns []string
+	resp.Diagnostics.Append(config.ResourceTypes.ElementsAs(ctx, &patterns, false)...)
+
+	overrides := make(map[string]string)
+	if !config.Overrides.IsNull() {
+		resp.Diagnostics.Append(config.Overrides.ElementsAs(ctx, &overrides, false)...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	configuration := config.Configuration
+
+	if configuration.IsNull() {
+		if d.defaults.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("configuration"), "No configuration",
+				"The configuration must be set when the provider has no default configuration.")
+			return
+		}
+
+		configuration = d.defaults
+	}
+
+	names, errors, known, diags := namepf.PreviewNames(ctx, configuration, patterns, overrides)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !known {
+		resp.Diagnostics.AddAttributeError(path.Root("configuration"), "Configuration not known",
+			"The names cannot be previewed because the configuration uses values which are not known until apply (e.g. attributes of resources).  "+
+				"Data sources are read while planning, so the configuration (including the default configuration of the provider) must only use values "+
+				"which are known at plan time.")
+		return
+	}
+
+	config.Names, diags = types.MapValueFrom(ctx, types.StringType, names)
+	resp.Diagnostics.Append(diags...)
+	config.Errors, diags = types.MapValueFrom(ctx, types.StringType, errors)
+	resp.Diagnostics.Append(diags...)
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "read name preview data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
@@ -0,0 +1,153 @@
+package datasource_test
+
+import (
+	"regexp"
+	"terraform-provider-namep/internal/acctest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccDataSourceNamePreview_glob(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "namep_azure_caf_types" "example" {}
+
+				data "namep_configuration" "example" {
+				  types = data.namep_azure_caf_types.example.types
+				  formats = {
+				    azure_dashes   = "#{SLUG}-#{APP}-#{NAME}"
+				    azure_nodashes = "#{SLUG}#{APP}#{NAME}"
+				  }
+				  variables = {
+				    app = "myapp"
+				  }
+				}
+
+				data "namep_name_preview" "example" {
+				  configuration  = data.namep_configuration.example.configuration
+				  resource_types = ["azurerm_resource_group", "azurerm_storage_acc*"]
+				  overrides = {
+				    name = "main"
+				  }
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.namep_name_preview.example",
+						tfjsonpath.New("names"),
+						knownvalue.MapExact(map[string]knownvalue.Check{
+							"azurerm_resource_group":  knownvalue.StringExact("rg-myapp-main"),
+							"azurerm_storage_account": knownvalue.StringExact("stmyappmain"),
+						}),
+					),
+					statecheck.ExpectKnownValue(
+						"data.namep_name_preview.example",
+						tfjsonpath.New("errors"),
+						knownvalue.MapExact(map[string]knownvalue.Check{}),
+					),
+				},
+			},
+		},
+	})
+}
+
+func TestAccDataSourceNamePreview_errors(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "namep_configuration" "example" {
+				  formats = {
+				    custom = "#{APP}-#{MISSING}"
+				  }
+				  variables = {
+				    app = "myapp"
+				  }
+				}
+
+				data "namep_name_preview" "example" {
+				  configuration  = data.namep_configuration.example.configuration
+				  resource_types = ["anything"]
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.namep_name_preview.example",
+						tfjsonpath.New("names"),
+						knownvalue.MapExact(map[string]knownvalue.Check{}),
+					),
+					statecheck.ExpectKnownValue(
+						"data.namep_name_preview.example",
+						tfjsonpath.New("errors"),
+						knownvalue.MapExact(map[string]knownvalue.Check{
+							"anything": knownvalue.StringRegexp(regexp.MustCompile(`No variable found for "MISSING"`)),
+						}),
+					),
+				},
+			},
+		},
+	})
+}
+
+func TestAccDataSourceNamePreview_provider_defaults(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `provider "namep" {
+				  formats = {
+				    k8s = "#{SLUG}-#{APP}"
+				  }
+				  variables = {
+				    app = "myapp"
+				  }
+				  types_sources = ["kubernetes"]
+				}
+
+				data "namep_name_preview" "example" {
+				  resource_types = ["kubernetes_namespace", "kubernetes_service"]
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.namep_name_preview.example",
+						tfjsonpath.New("names"),
+						knownvalue.MapExact(map[string]knownvalue.Check{
+							"kubernetes_namespace": knownvalue.StringExact("ns-myapp"),
+							"kubernetes_service":   knownvalue.StringExact("svc-myapp"),
+						}),
+					),
+				},
+			},
+			{
+				Config: `data "namep_name_preview" "example" {
+				  resource_types = ["kubernetes_namespace"]
+				}`,
+				ExpectError: regexp.MustCompile(`The\s+configuration\s+must\s+be\s+set`),
+			},
+			{
+				Config: `resource "terraform_data" "app" {
+				  input = "myapp"
+				}
+
+				provider "namep" {
+				  formats = {
+				    k8s = "#{SLUG}-#{APP}"
+				  }
+				  variables = {
+				    app = terraform_data.app.output
+				  }
+				  types_sources = ["kubernetes"]
+				}
+
+				data "namep_name_preview" "example" {
+				  resource_types = ["kubernetes_namespace"]
+				}`,
+				ExpectError: regexp.MustCompile(`Configuration\s+not\s+known`),
+			},
+		},
+	})
+}
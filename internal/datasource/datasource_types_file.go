@@ -0,0 +1,241 @@
+package datasource
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+
+	"terraform-provider-namep/internal/shared"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"gopkg.in/yaml.v3"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource = &typesFileDataSource{}
+)
+
+// New is a helper function to simplify the provider implementation.
+func NewTypesFile() datasource.DataSource {
+	return &typesFileDataSource{}
+}
+
+// data source implementation.
+type typesFileDataSource struct{}
+
+type typesFileDataSourceModel struct {
+	Path  types.String `tfsdk:"path"`
+	Types types.Map    `tfsdk:"types"`
+}
+
+func (d *typesFileDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_types_file"
+}
+
+func (d *typesFileDataSource) Schema(ctx context.Context, ds datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `This data resource loads a map of type names to type information from a YAML or JSON file, for resource types which are not provided by any of the
+other types data sources (e.g. in-house resource types).  The file is a map from the type name to the fields of the type, which are the same as the fields of the
+` + "`types`" + ` parameter in the [namep_configuration](configuration.md) data source:
+
+` + "```yaml" + `
+vault_path:
+  slug: vp
+  max_length: 128
+  lowercase: true
+  validation_regex: "^[a-z0-9/_-]{1,128}$"
+  cleanup_regex: "[^a-z0-9/_-]"
+  default_selector: vault
+` + "```" + `
+
+All fields are optional: ` + "`name`" + ` defaults to the type name, ` + "`default_selector`" + ` defaults to "custom" and the other fields default to their zero value (an empty
+validation regex accepts every name).  The file is validated when it is loaded: unknown fields, values of the wrong type and regexes which do not compile are all
+reported as errors with the line number in the file.
+
+The result can be merged with the types of other data sources (e.g. ` + "`merge(data.namep_azure_caf_types.example.types, data.namep_types_file.example.types)`" + `).
+`,
+		Attributes: map[string]schema.Attribute{
+			"path": schema.StringAttribute{
+				Description: "Path of the YAML or JSON file to load the types from (relative paths are relative to the working directory of Terraform, use `path.module` for files in a module).",
+				Required:    true,
+			},
+			"types": schema.MapAttribute{
+				Description: "The type info map loaded from the file.",
+				Computed:    true,
+				ElementType: typesAttributes(),
+			},
+		},
+	}
+}
+
+func (d *typesFileDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config typesFileDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filename := config.Path.ValueString()
+	content, err := os.ReadFile(filename)
+
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("path"), "Failed to read types file", err.Error())
+		return
+	}
+
+	typeInfoMap, errs := loadTypes(filename, content)
+
+	for _, err := range errs {
+		resp.Diagnostics.AddAttributeError(path.Root("path"), "Invalid types file", err)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, diag := types.MapValueFrom(ctx, typesAttributes(), typeInfoMap)
+
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag.Errors()...)
+		return
+	}
+
+	config.Types = result
+
+	tflog.Trace(ctx, "read types file data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+// fileErrors collects the problems found in a file, prefixed with the file name and line number.
+type fileErrors struct {
+	filename string
+	errs     []string
+}
+
+func (e *fileErrors) at(node *yaml.Node, format string, args ...any) {
+	e.errs = append(e.errs, fmt.Sprintf("%s:%d: %s", e.filename, node.Line, fmt.Sprintf(format, args...)))
+}
+
+// parseYAML parses a YAML or JSON file (JSON being a subset of YAML).  The root node is nil if the file is empty or cannot be parsed.
+func parseYAML(filename string, content []byte, errs *fileErrors) *yaml.Node {
+	var doc yaml.Node
+
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		errs.errs = append(errs.errs, fmt.Sprintf("%s: %v", filename, err))
+		return nil
+	}
+
+	if len(doc.Content) == 0 {
+		return nil
+	}
+
+	return doc.Content[0]
+}
+
+// loadTypes parses the types of a YAML or JSON file.  Every problem found is returned, prefixed with the file name and line number.
+func loadTypes(filename string, content []byte) (map[string]shared.TypeFields, []string) {
+	errs := &fileErrors{filename: filename}
+	root := parseYAML(filename, content, errs)
+
+	if root == nil {
+		return make(map[string]shared.TypeFields), errs.errs
+	}
+
+	return decodeTypes(root, errs), errs.errs
+}
+
+// decodeTypes decodes a map node of type names to type information, see the description of the namep_types_file data source.
+func decodeTypes(node *yaml.Node, errs *fileErrors) map[string]shared.TypeFields {
+	typeInfoMap := make(map[string]shared.TypeFields)
+
+	if node.Kind != yaml.MappingNode {
+		errs.at(node, "expected a map of type names to type information")
+		return typeInfoMap
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valueNode := node.Content[i], node.Content[i+1]
+		resourceType := keyNode.Value
+
+		if _, exists := typeInfoMap[resourceType]; exists {
+			errs.at(keyNode, "type %q is defined more than once", resourceType)
+			continue
+		}
+
+		if valueNode.Kind != yaml.MappingNode {
+			errs.at(valueNode, "type %q must be a map of type fields", resourceType)
+			continue
+		}
+
+		typeInfo := shared.TypeFields{
+			Name:            resourceType,
+			DefaultSelector: "custom",
+		}
+		fieldNodes := make(map[string]*yaml.Node)
+
+		for j := 0; j+1 < len(valueNode.Content); j += 2 {
+			fieldNode, fieldValue := valueNode.Content[j], valueNode.Content[j+1]
+			var err error
+
+			if _, exists := fieldNodes[fieldNode.Value]; exists {
+				errs.at(fieldNode, "field %q is defined more than once in type %q", fieldNode.Value, resourceType)
+				continue
+			}
+
+			switch fieldNode.Value {
+			case "name":
+				err = fieldValue.Decode(&typeInfo.Name)
+			case "slug":
+				err = fieldValue.Decode(&typeInfo.Slug)
+			case "min_length":
+				err = fieldValue.Decode(&typeInfo.MinLength)
+			case "max_length":
+				err = fieldValue.Decode(&typeInfo.MaxLength)
+			case "lowercase":
+				err = fieldValue.Decode(&typeInfo.Lowercase)
+			case "validation_regex":
+				err = fieldValue.Decode(&typeInfo.ValidationRegex)
+			case "cleanup_regex":
+				err = fieldValue.Decode(&typeInfo.CleanupRegex)
+			case "default_selector":
+				err = fieldValue.Decode(&typeInfo.DefaultSelector)
+			default:
+				errs.at(fieldNode, "unknown field %q in type %q", fieldNode.Value, resourceType)
+				continue
+			}
+
+			if err != nil || fieldValue.Kind != yaml.ScalarNode {
+				errs.at(fieldValue, "invalid value for field %q in type %q", fieldNode.Value, resourceType)
+				continue
+			}
+
+			fieldNodes[fieldNode.Value] = fieldValue
+		}
+
+		for _, field := range []string{"validation_regex", "cleanup_regex"} {
+			if regexNode, exists := fieldNodes[field]; exists {
+				if _, err := regexp.Compile(regexNode.Value); err != nil {
+					errs.at(regexNode, "invalid %s in type %q: %v", field, resourceType, err)
+				}
+			}
+		}
+
+		if typeInfo.MaxLength > 0 && typeInfo.MinLength > typeInfo.MaxLength {
+			errs.at(fieldNodes["min_length"], "min_length (%d) of type %q is greater than max_length (%d)", typeInfo.MinLength, resourceType, typeInfo.MaxLength)
+		}
+
+		typeInfoMap[resourceType] = typeInfo
+	}
+
+	return typeInfoMap
+}
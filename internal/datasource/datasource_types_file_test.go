@@ -0,0 +1,123 @@
+package datasource_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"terraform-provider-namep/internal/acctest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func writeTestFile(t *testing.T, name string, content string) string {
+	filename := filepath.Join(t.TempDir(), name)
+
+	if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	return filename
+}
+
+func TestAccDataSourceTypesFile_yaml(t *testing.T) {
+	filename := writeTestFile(t, "types.yaml", `
+vault_path:
+  slug: vp
+  max_length: 128
+  lowercase: true
+  validation_regex: "^[a-z0-9/_-]{1,128}$"
+  default_selector: vault
+dns_record: {}
+`)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`data "namep_types_file" "example" {
+					path = %q
+				}`, filename),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.namep_types_file.example",
+						tfjsonpath.New("types"),
+						knownvalue.MapExact(map[string]knownvalue.Check{
+							"vault_path": knownvalue.ObjectExact(map[string]knownvalue.Check{
+								"name":             knownvalue.StringExact("vault_path"),
+								"slug":             knownvalue.StringExact("vp"),
+								"min_length":       knownvalue.Int64Exact(0),
+								"max_length":       knownvalue.Int64Exact(128),
+								"lowercase":        knownvalue.Bool(true),
+								"validation_regex": knownvalue.StringExact("^[a-z0-9/_-]{1,128}$"),
+								"cleanup_regex":    knownvalue.StringExact(""),
+								"default_selector": knownvalue.StringExact("vault"),
+							}),
+							"dns_record": knownvalue.ObjectPartial(map[string]knownvalue.Check{
+								"name":             knownvalue.StringExact("dns_record"),
+								"default_selector": knownvalue.StringExact("custom"),
+							}),
+						}),
+					),
+				},
+			},
+		},
+	})
+}
+
+func TestAccDataSourceTypesFile_json(t *testing.T) {
+	filename := writeTestFile(t, "types.json", `{
+	"databricks_cluster": {
+		"slug": "dbc",
+		"max_length": 100
+	}
+}`)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`data "namep_types_file" "example" {
+					path = %q
+				}`, filename),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.namep_types_file.example",
+						tfjsonpath.New("types"),
+						knownvalue.MapExact(map[string]knownvalue.Check{
+							"databricks_cluster": knownvalue.ObjectPartial(map[string]knownvalue.Check{
+								"slug":       knownvalue.StringExact("dbc"),
+								"max_length": knownvalue.Int64Exact(100),
+							}),
+						}),
+					),
+				},
+			},
+		},
+	})
+}
+
+func TestAccDataSourceTypesFile_errors(t *testing.T) {
+	filename := writeTestFile(t, "types.yaml", `vault_path:
+  slug: vp
+  max_length: lots
+  colour: red
+  validation_regex: "[a-"
+`)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`data "namep_types_file" "example" {
+					path = %q
+				}`, filename),
+				ExpectError: regexp.MustCompile(`(?s)types\.yaml:3:\s+invalid\s+value\s+for\s+field\s+"max_length".*types\.yaml:4:\s+unknown\s+field\s+"colour".*types\.yaml:5:\s+invalid\s+validation_regex`),
+			},
+		},
+	})
+}
@@ -0,0 +1,18 @@
+package datasource
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+)
+
+// SetAzureCredential makes the data sources use the credential for Azure requests until the end of the test.
+func SetAzureCredential(t *testing.T, cred azcore.TokenCredential) {
+	newAzureCredentialOrig := newAzureCredential
+	newAzureCredential = func(*azidentity.DefaultAzureCredentialOptions) (azcore.TokenCredential, error) {
+		return cred, nil
+	}
+
+	t.Cleanup(func() { newAzureCredential = newAzureCredentialOrig })
+}
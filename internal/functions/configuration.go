@@ -0,0 +1,293 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type typeFields struct {
+	Name              string `tfsdk:"name"`
+	Slug              string `tfsdk:"slug"`
+	MinLength         int    `tfsdk:"min_length"`
+	MaxLength         int    `tfsdk:"max_length"`
+	Lowercase         bool   `tfsdk:"lowercase"`
+	ValidatationRegex string `tfsdk:"validation_regex"`
+	CleanupRegex      string `tfsdk:"cleanup_regex"`
+	DefaultSelector   string `tfsdk:"default_selector"`
+}
+
+// nameConfiguration is the configuration object passed to the functions, converted once so that any number of names can be
+// generated from it without converting the (often large) types map again.
+type nameConfiguration struct {
+	variables    map[string]types.String
+	formats      map[string]types.String
+	variableMaps map[string](map[string]types.String)
+	types        map[string]types.Object
+
+	// variableMapKeys maps the uppercased keys of each variable map to the keys as they were configured
+	variableMapKeys map[string](map[string]string)
+
+	positions argumentPositions
+}
+
+// argumentPositions are the positions of the arguments of a function, so errors can point at the argument which caused them.
+type argumentPositions struct {
+	resourceType  int64
+	configuration int64
+	overrides     int64 // position of the first override
+}
+
+func configurationParameter(name string) function.ObjectParameter {
+	return function.ObjectParameter{
+		Name:               name,
+		Description:        "A configuration object that contains the variables and formats to use for the name.",
+		AllowUnknownValues: true,
+		AttributeTypes:     ConfigurationAttributeTypes(),
+	}
+}
+
+// ConfigurationAttributeTypes returns the attribute types of a configuration object, as produced by the namep_configuration data source.
+func ConfigurationAttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"variables":     types.MapType{ElemType: types.StringType},
+		"formats":       types.MapType{ElemType: types.StringType},
+		"variable_maps": types.MapType{ElemType: types.MapType{ElemType: types.StringType}},
+		"types": types.MapType{
+			ElemType: types.ObjectType{
+				AttrTypes: map[string]attr.Type{
+					"name":             types.StringType,
+					"slug":             types.StringType,
+					"min_length":       types.Int32Type,
+					"max_length":       types.Int32Type,
+					"lowercase":        types.BoolType,
+					"validation_regex": types.StringType,
+					"cleanup_regex":    types.StringType,
+					"default_selector": types.StringType,
+				},
+			},
+		},
+	}
+}
+
+// newNameConfiguration converts the configuration object.  If the configuration (or any of its top level maps) is unknown, known is false
+// and names should be computed in a later phase where at least those are known.
+func newNameConfiguration(ctx context.Context, configurationsObj types.Object, positions argumentPositions) (config *nameConfiguration, known bool, funcErr *function.FuncError) {
+	if configurationsObj.IsUnknown() {
+		return nil, false, nil
+	}
+
+	defer func() {
+		funcErr = atArgument(positions.configuration, funcErr)
+	}()
+
+	var cfgs struct {
+		Variables    types.Map `tfsdk:"variables"`
+		Formats      types.Map `tfsdk:"formats"`
+		VariableMaps types.Map `tfsdk:"variable_maps"`
+		Types        types.Map `tfsdk:"types"`
+	}
+
+	diags := configurationsObj.As(ctx, &cfgs, basetypes.ObjectAsOptions{})
+	funcErr = function.ConcatFuncErrors(funcErr, function.FuncErrorFromDiags(ctx, diags))
+
+	if cfgs.Formats.IsUnknown() || cfgs.Types.IsUnknown() || cfgs.Variables.IsUnknown() || cfgs.VariableMaps.IsUnknown() {
+		// if the top level maps are unknown then skip for a later phase where at least those are known
+		return nil, false, funcErr
+	}
+
+	var configurationsArg struct {
+		Variables    map[string]types.String `tfsdk:"variables"`
+		Formats      map[string]types.String `tfsdk:"formats"`
+		VariableMaps map[string]types.Map    `tfsdk:"variable_maps"`
+		Types        map[string]types.Object `tfsdk:"types"`
+	}
+
+	diags = configurationsObj.As(ctx, &configurationsArg, basetypes.ObjectAsOptions{})
+	funcErr = function.ConcatFuncErrors(funcErr, function.FuncErrorFromDiags(ctx, diags))
+
+	if funcErr != nil {
+		return nil, false, funcErr
+	}
+
+	variableMaps := make(map[string](map[string]types.String), len(configurationsArg.VariableMaps))
+	variableMapKeys := make(map[string](map[string]string), len(configurationsArg.VariableMaps))
+
+	for k, v := range configurationsArg.VariableMaps {
+		if v.IsUnknown() {
+			return nil, false, funcErr
+		}
+
+		vm := make(map[string]types.String, len(v.Elements()))
+		diags = v.ElementsAs(ctx, &vm, false)
+		funcErr = function.ConcatFuncErrors(funcErr, function.FuncErrorFromDiags(ctx, diags))
+
+		variableMaps[strings.ToUpper(k)] = keysToUpper(vm)
+		variableMapKeys[strings.ToUpper(k)] = make(map[string]string, len(vm))
+
+		for key := range vm {
+			variableMapKeys[strings.ToUpper(k)][strings.ToUpper(key)] = key
+		}
+	}
+
+	return &nameConfiguration{
+		variables:       keysToUpper(configurationsArg.Variables),
+		formats:         configurationsArg.Formats,
+		variableMaps:    variableMaps,
+		types:           configurationsArg.Types,
+		variableMapKeys: variableMapKeys,
+		positions:       positions,
+	}, true, funcErr
+}
+
+// typeInfo returns the type information for the resource type, or the information for a "custom" type if the resource type is not in the types map.
+func (c *nameConfiguration) typeInfo(ctx context.Context, resourceType string) (typeInfo typeFields, known bool, funcErr *function.FuncError) {
+	typeInfo = typeFields{
+		DefaultSelector:   "custom",
+		ValidatationRegex: ".*", // No possible validation for default custom names
+	}
+
+	o, exists := c.types[resourceType]
+
+	if !exists {
+		return typeInfo, true, nil
+	}
+
+	if o.IsUnknown() {
+		return typeInfo, false, nil
+	}
+
+	diag := o.As(ctx, &typeInfo, basetypes.ObjectAsOptions{})
+
+	return typeInfo, true, atArgument(c.positions.configuration, function.FuncErrorFromDiags(ctx, diag))
+}
+
+// findFormat finds the format for the resource type as described in the "Format Resolution" section of the documentation.  The list of
+// selectors which were searched is always returned.
+func (c *nameConfiguration) findFormat(ctx context.Context, resourceType string, typeInfo typeFields) (formatKey string, format types.String, toSearch []string, exists bool) {
+	toSearch = formatSearchStrings(resourceType, typeInfo.DefaultSelector)
+
+	for _, search := range toSearch {
+		tflog.Debug(ctx, fmt.Sprintf("searching for format: %q", search))
+		format, exists = c.formats[search]
+
+		if exists {
+			return search, format, toSearch, true
+		}
+	}
+
+	return "", format, toSearch, false
+}
+
+// withOverrides returns the variables of the configuration with the overrides applied in order, and the index of the override which set
+// each overridden variable.
+func (c *nameConfiguration) withOverrides(overrides []map[string]string) (map[string]types.String, map[string]int, *function.FuncError) {
+	var funcErr *function.FuncError
+	variables := make(map[string]types.String, len(c.variables))
+	overriddenBy := make(map[string]int)
+
+	for k, v := range c.variables {
+		variables[k] = v
+	}
+
+	for i, overrideValue := range overrides {
+		if overrideValue == nil {
+			funcErr = function.ConcatFuncErrors(funcErr, function.NewArgumentFuncError(c.positions.overrides+int64(i), "Got null map for override"))
+			continue
+		}
+
+		for k, v := range overrideValue {
+			variables[strings.ToUpper(k)] = types.StringValue(v)
+			overriddenBy[strings.ToUpper(k)] = i
+		}
+	}
+
+	return variables, overriddenBy, funcErr
+}
+
+// argumentOf returns the position of the argument which set the variable: the override which set it last or the configuration.
+func (c *nameConfiguration) argumentOf(overriddenBy map[string]int) func(variable string) int64 {
+	return func(variable string) int64 {
+		if i, overridden := overriddenBy[strings.ToUpper(variable)]; overridden {
+			return c.positions.overrides + int64(i)
+		}
+
+		return c.positions.configuration
+	}
+}
+
+// generateName computes the name for the resource type.  The result is unknown if any value needed to compute it is unknown.  If trace is not
+// nil, the steps taken to compute the name are recorded in it.
+func (c *nameConfiguration) generateName(ctx context.Context, resourceType string, overrides []map[string]string, trace *nameTrace) (types.String, *function.FuncError) {
+	typeInfo, known, funcErr := c.typeInfo(ctx, resourceType)
+
+	if funcErr != nil || !known {
+		return types.StringUnknown(), funcErr
+	}
+
+	formatKey, format, toSearch, exists := c.findFormat(ctx, resourceType, typeInfo)
+
+	if trace != nil {
+		trace.typeInfo = typeInfo
+		trace.candidates = toSearch
+		trace.selector = formatKey
+		trace.format = format
+	}
+
+	if !exists {
+		return types.StringUnknown(), function.NewArgumentFuncError(c.positions.resourceType, fmt.Sprintf("No format found for resource type %q, tried %v", resourceType, toSearch))
+	}
+
+	if format.IsUnknown() {
+		return types.StringUnknown(), nil
+	}
+
+	variables, overriddenBy, funcErr := c.withOverrides(overrides)
+
+	if trace != nil {
+		for k := range overriddenBy {
+			trace.overridden[k] = true
+		}
+	}
+
+	result, err := calculateName(typeInfo, formatKey, format.ValueString(), variables, c.variableMaps, c.argumentOf(overriddenBy), trace)
+
+	return result, function.ConcatFuncErrors(funcErr, err)
+}
+
+func formatSearchStrings(resourceType string, defaultSelector string) []string {
+	var result []string
+	result = append(result, resourceType)
+	result = append(result, defaultSelector)
+
+	parts := strings.Split(defaultSelector, "_")
+
+	for i := len(parts) - 1; i > 0; i-- {
+		result = append(result, strings.Join(parts[:i], "_"))
+	}
+
+	return result
+}
+
+// atArgument ties an error which is not tied to an argument yet to the argument at the position.
+func atArgument(position int64, funcErr *function.FuncError) *function.FuncError {
+	if funcErr == nil || funcErr.FunctionArgument != nil {
+		return funcErr
+	}
+
+	return function.NewArgumentFuncError(position, funcErr.Text)
+}
+
+func keysToUpper(m map[string]types.String) map[string]types.String {
+	newMap := make(map[string]types.String, len(m))
+	for k, v := range m {
+		newMap[strings.ToUpper(k)] = v
+	}
+	return newMap
+}
@@ -0,0 +1,120 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// PreviewNames generates the names of the resource types matching the patterns, which are either resource types or globs (e.g.
+// "azurerm_storage_*") matched against the types of the configuration.  Names which cannot be generated are reported in the errors map
+// instead of failing.  If the configuration is not known yet, known is false.
+func PreviewNames(ctx context.Context, configuration types.Object, patterns []string, overrides map[string]string) (names map[string]string, errors map[string]string, known bool, diags diag.Diagnostics) {
+	config, known, funcErr := newNameConfiguration(ctx, configuration, argumentPositions{})
+
+	if funcErr != nil {
+		diags.AddError("Invalid configuration", funcErr.Text)
+		return nil, nil, false, diags
+	}
+
+	if !known {
+		return nil, nil, false, diags
+	}
+
+	resourceTypes, err := config.matchTypes(patterns)
+
+	if err != nil {
+		diags.AddError("Invalid resource type pattern", err.Error())
+		return nil, nil, false, diags
+	}
+
+	names = make(map[string]string, len(resourceTypes))
+	errors = make(map[string]string)
+
+	for _, resourceType := range resourceTypes {
+		name, funcErr := config.generateName(ctx, resourceType, []map[string]string{overrides}, nil)
+
+		if funcErr != nil {
+			errors[resourceType] = funcErr.Text
+			continue
+		}
+
+		if name.IsUnknown() {
+			return nil, nil, false, diags
+		}
+
+		names[resourceType] = name.ValueString()
+	}
+
+	return names, errors, true, diags
+}
+
+// matchTypes returns the sorted resource types matching the patterns.  A pattern without glob characters is returned as is, even if it is not
+// in the types of the configuration, the same way the namestring function accepts any resource type.
+func (c *nameConfiguration) matchTypes(patterns []string) ([]string, error) {
+	matched := make(map[string]bool)
+
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %v", pattern, err)
+		}
+
+		if _, exists := c.types[pattern]; exists || !strings.ContainsAny(pattern, `*?[\`) {
+			matched[pattern] = true
+			continue
+		}
+
+		for resourceType := range c.types {
+			if ok, _ := path.Match(pattern, resourceType); ok {
+				matched[resourceType] = true
+			}
+		}
+	}
+
+	result := make([]string, 0, len(matched))
+	for resourceType := range matched {
+		result = append(result, resourceType)
+	}
+	sort.Strings(result)
+
+	return result, nil
+}
+
+// GenerateName generates the name of the resource type the same way as the namestring function, with a single map of overrides (which may be
+// nil).  If the configuration or anything needed to generate the name is not known yet, known is false.
+func GenerateName(ctx context.Context, configuration types.Object, resourceType string, overrides map[string]string) (name string, known bool, diags diag.Diagnostics) {
+	config, known, funcErr := newNameConfiguration(ctx, configuration, argumentPositions{})
+
+	if funcErr != nil {
+		diags.AddError("Invalid configuration", funcErr.Text)
+		return "", false, diags
+	}
+
+	if !known {
+		return "", false, diags
+	}
+
+	var overridesList []map[string]string
+
+	if overrides != nil {
+		overridesList = append(overridesList, overrides)
+	}
+
+	result, funcErr := config.generateName(ctx, resourceType, overridesList, nil)
+
+	if funcErr != nil {
+		diags.AddError(fmt.Sprintf("Cannot generate name for resource type %q", resourceType), funcErr.Text)
+		return "", false, diags
+	}
+
+	if result.IsUnknown() {
+		return "", false, diags
+	}
+
+	return result.ValueString(), true, diags
+}
@@ -0,0 +1,150 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ValidateConfiguration renders the format of every type in the configuration with its variables (overridden by the sample variables) and
+// reports every name which cannot be generated or does not pass the validation of its type as an error.  Types without a format and
+// variables which are not used by any format are reported as warnings.
+func ValidateConfiguration(ctx context.Context, configuration types.Object, samples map[string]string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	config, known, funcErr := newNameConfiguration(ctx, configuration, argumentPositions{})
+
+	if funcErr != nil {
+		diags.AddError("Invalid configuration", funcErr.Text)
+		return diags
+	}
+
+	if !known {
+		return diags
+	}
+
+	typeKeys := make([]string, 0, len(config.types))
+	for k := range config.types {
+		typeKeys = append(typeKeys, k)
+	}
+	sort.Strings(typeKeys)
+
+	for _, resourceType := range typeKeys {
+		typeInfo, known, funcErr := config.typeInfo(ctx, resourceType)
+
+		if funcErr != nil {
+			diags.AddAttributeError(path.Root("types").AtMapKey(resourceType), fmt.Sprintf("Invalid type %q", resourceType), funcErr.Text)
+			continue
+		}
+
+		if !known {
+			continue
+		}
+
+		formatKey, format, toSearch, exists := config.findFormat(ctx, resourceType, typeInfo)
+
+		if !exists {
+			diags.AddAttributeWarning(path.Root("types").AtMapKey(resourceType), fmt.Sprintf("No format for type %q", resourceType),
+				fmt.Sprintf("Names cannot be generated for type %q as there is no format for any of %v", resourceType, toSearch))
+			continue
+		}
+
+		if format.IsUnknown() {
+			continue
+		}
+
+		_, funcErr = config.generateName(ctx, resourceType, []map[string]string{samples}, nil)
+
+		if funcErr != nil {
+			for _, text := range strings.Split(funcErr.Text, "\n") {
+				diags.AddAttributeError(path.Root("formats").AtMapKey(formatKey), fmt.Sprintf("Invalid name for type %q", resourceType), text)
+			}
+		}
+	}
+
+	used := usedVariables(config.formats)
+
+	variables, _ := configuration.Attributes()["variables"].(types.Map)
+	variableKeys := make([]string, 0, len(variables.Elements()))
+	for k := range variables.Elements() {
+		variableKeys = append(variableKeys, k)
+	}
+	sort.Strings(variableKeys)
+
+	for _, k := range variableKeys {
+		if !used[strings.ToUpper(k)] {
+			diags.AddAttributeWarning(path.Root("variables").AtMapKey(k), fmt.Sprintf("Unused variable %q", k),
+				fmt.Sprintf("The variable %q is not used by any format", k))
+		}
+	}
+
+	return diags
+}
+
+// usedVariables returns the (uppercased) names of the variables referenced by the formats, including those in conditions and variable map
+// lookups.
+func usedVariables(formats map[string]types.String) (used map[string]bool) {
+	used = make(map[string]bool)
+
+	for _, format := range formats {
+		if format.IsUnknown() || format.IsNull() {
+			continue
+		}
+
+		for _, token := range formatToken.FindAllString(format.ValueString(), -1) {
+			body := strings.TrimSpace(token[2 : len(token)-1])
+			var refs []string
+
+			switch {
+			case body == "end" || body == "/" || body == "else":
+				continue
+			case strings.HasPrefix(body, "?") || ifToken.MatchString(body):
+				condition := strings.TrimSpace(strings.TrimPrefix(body, "?"))
+				if ifToken.MatchString(body) {
+					condition = ifToken.FindStringSubmatch(body)[1]
+				}
+
+				if matches := conditionExpression.FindStringSubmatch(strings.TrimSpace(condition)); matches != nil {
+					refs = append(refs, matches[2])
+
+					if _, isLiteral := unquoteLiteral(strings.TrimSpace(matches[4])); matches[4] != "" && !isLiteral {
+						refs = append(refs, strings.TrimSpace(matches[4]))
+					}
+				}
+			case strings.TrimSpace(strings.Split(body, "|")[0]) == "":
+				continue
+			default:
+				head, _, _ := preprocessToken(strings.TrimSpace(strings.Split(body, "|")[0]))
+				head, _, _ = variableFallback(head)
+				head = strings.TrimSpace(head)
+
+				if head == "SLUG" {
+					continue
+				}
+
+				if hashToken.MatchString(head) {
+					hashRefs, _, _, _ := parseHashToken(head)
+
+					for _, ref := range strings.Split(hashRefs, ",") {
+						refs = append(refs, strings.TrimSpace(ref))
+					}
+				} else {
+					refs = append(refs, head)
+				}
+			}
+
+			for _, ref := range refs {
+				if v := rootVariable(ref); v != "" {
+					used[strings.ToUpper(v)] = true
+				}
+			}
+		}
+	}
+
+	return used
+}
@@ -0,0 +1,148 @@
+package functions
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	formatToken         = regexp.MustCompile(`#\{[^}]+}`)
+	ifToken             = regexp.MustCompile(`^if\s+(.+)$`)
+	conditionExpression = regexp.MustCompile(`^(!?)\s*([^\s!=]+)\s*(?:(==|!=)\s*(.+))?$`)
+)
+
+type conditionFrame struct {
+	parentActive bool
+	matched      bool
+	active       bool
+	elseSeen     bool
+}
+
+// evaluateConditionals removes the conditional segments of the format whose conditions do not hold, along with all the conditional
+// tokens themselves.  The supported conditionals are "#{if EXPR}...#{else}...#{end}" and the short form "#{?VAR}...#{/}".
+// If a condition which needs to be evaluated is unknown, unknown is returned as true.
+func evaluateConditionals(format string, variables map[string]types.String, variableMaps map[string](map[string]types.String)) (result string, unknown bool, err error) {
+	var sb strings.Builder
+	var stack []*conditionFrame
+
+	active := func() bool {
+		return len(stack) == 0 || stack[len(stack)-1].active
+	}
+
+	pos := 0
+
+	for _, loc := range formatToken.FindAllStringIndex(format, -1) {
+		if active() {
+			sb.WriteString(format[pos:loc[0]])
+		}
+		pos = loc[1]
+
+		token := format[loc[0]:loc[1]]
+		body := strings.TrimSpace(token[2 : len(token)-1])
+		var condition string
+
+		switch {
+		case body == "end" || body == "/":
+			if len(stack) == 0 {
+				return format, false, fmt.Errorf("%q without a matching condition", token)
+			}
+			stack = stack[:len(stack)-1]
+			continue
+		case body == "else":
+			if len(stack) == 0 || stack[len(stack)-1].elseSeen {
+				return format, false, fmt.Errorf("%q without a matching condition", token)
+			}
+			frame := stack[len(stack)-1]
+			frame.elseSeen = true
+			frame.active = frame.parentActive && !frame.matched
+			continue
+		case strings.HasPrefix(body, "?"):
+			condition = strings.TrimSpace(body[1:])
+		case ifToken.MatchString(body):
+			condition = ifToken.FindStringSubmatch(body)[1]
+		default:
+			if active() {
+				sb.WriteString(token)
+			}
+			continue
+		}
+
+		frame := &conditionFrame{parentActive: active()}
+
+		if frame.parentActive {
+			matched, condUnknown, condErr := evaluateCondition(condition, variables, variableMaps)
+
+			if condErr != nil {
+				return format, false, fmt.Errorf("invalid condition in %q: %v", token, condErr)
+			}
+
+			if condUnknown {
+				return format, true, nil
+			}
+
+			frame.matched = matched
+			frame.active = matched
+		}
+
+		stack = append(stack, frame)
+	}
+
+	if len(stack) > 0 {
+		return format, false, fmt.Errorf("missing %q for a condition", "#{end}")
+	}
+
+	sb.WriteString(format[pos:])
+
+	return sb.String(), false, nil
+}
+
+// evaluateCondition evaluates one of "VAR" (VAR is not empty), "!VAR" (VAR is empty), "VAR == VALUE" or "VAR != VALUE", where VALUE is either a
+// quoted literal or another variable.  Variables which cannot be found are treated as empty.
+func evaluateCondition(condition string, variables map[string]types.String, variableMaps map[string](map[string]types.String)) (result bool, unknown bool, err error) {
+	matches := conditionExpression.FindStringSubmatch(strings.TrimSpace(condition))
+
+	if matches == nil || (matches[1] == "!" && matches[3] != "") {
+		return false, false, fmt.Errorf("cannot parse %q", condition)
+	}
+
+	left, unknown := conditionValue(matches[2], variables, variableMaps)
+
+	if unknown {
+		return false, true, nil
+	}
+
+	if matches[3] == "" {
+		return (left != "") != (matches[1] == "!"), false, nil
+	}
+
+	right := strings.TrimSpace(matches[4])
+
+	if literal, isLiteral := unquoteLiteral(right); isLiteral {
+		right = literal
+	} else {
+		right, unknown = conditionValue(right, variables, variableMaps)
+
+		if unknown {
+			return false, true, nil
+		}
+	}
+
+	return (left == right) == (matches[3] == "=="), false, nil
+}
+
+func conditionValue(ref string, variables map[string]types.String, variableMaps map[string](map[string]types.String)) (string, bool) {
+	v, err := resolveVariable(ref, variables, variableMaps)
+
+	if err != nil {
+		return "", false
+	}
+
+	if v.IsUnknown() {
+		return "", true
+	}
+
+	return v.ValueString(), false
+}
@@ -0,0 +1,152 @@
+package functions
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type tokenFilter struct {
+	minArgs int
+	maxArgs int
+	apply   func(value string, args []string) (string, error)
+}
+
+var nonAlphanumeric = regexp.MustCompile(`[^a-zA-Z0-9]`)
+
+// tokenFilters are the filters which can be used in the pipeline of a format token (e.g. "#{NAME|lower|trunc:8}").
+var tokenFilters = map[string]tokenFilter{
+	"lower": {0, 0, func(value string, _ []string) (string, error) {
+		return strings.ToLower(value), nil
+	}},
+	"upper": {0, 0, func(value string, _ []string) (string, error) {
+		return strings.ToUpper(value), nil
+	}},
+	"trim": {0, 0, func(value string, _ []string) (string, error) {
+		return strings.TrimSpace(value), nil
+	}},
+	"alnum": {0, 0, func(value string, _ []string) (string, error) {
+		return nonAlphanumeric.ReplaceAllString(value, ""), nil
+	}},
+	"trunc": {1, 1, firstFilter},
+	"first": {1, 1, firstFilter},
+	"last": {1, 1, func(value string, args []string) (string, error) {
+		n, err := filterLength(args[0])
+		if err != nil {
+			return value, err
+		}
+		if len(value) > n {
+			return value[len(value)-n:], nil
+		}
+		return value, nil
+	}},
+	"replace": {2, 2, func(value string, args []string) (string, error) {
+		if args[0] == "" {
+			return value, fmt.Errorf("nothing to replace")
+		}
+		return strings.ReplaceAll(value, args[0], args[1]), nil
+	}},
+	"pad": {1, 2, func(value string, args []string) (string, error) {
+		n, err := filterLength(args[0])
+		if err != nil {
+			return value, err
+		}
+		padding := "0"
+		if len(args) > 1 {
+			padding = args[1]
+		}
+		if len(padding) != 1 {
+			return value, fmt.Errorf("padding must be a single character, got %q", padding)
+		}
+		if len(value) < n {
+			return strings.Repeat(padding, n-len(value)) + value, nil
+		}
+		return value, nil
+	}},
+}
+
+// tokenPipeline splits the body of a token (between "#{" and "}") into the variable and its filters.  An optional dash after the filters
+// (e.g. "NAME|lower-") belongs to the variable, unless it is an argument of the last filter (e.g. "NAME|replace:_:-").
+func tokenPipeline(body string) []string {
+	pipeline := strings.Split(body, "|")
+	last := len(pipeline) - 1
+
+	if last == 0 || strings.TrimSpace(pipeline[0]) == "" {
+		return pipeline
+	}
+
+	if trimmed, hasDash := strings.CutSuffix(strings.TrimSpace(pipeline[last]), "-"); hasDash && !validFilter(pipeline[last]) && validFilter(trimmed) {
+		pipeline[0] = strings.TrimSpace(pipeline[0]) + "-"
+		pipeline[last] = trimmed
+	}
+
+	return pipeline
+}
+
+// validFilter reports whether the filter (including shrink) and its arguments are valid.
+func validFilter(f string) bool {
+	filters, _, err := extractShrink([]string{f})
+
+	if err == nil {
+		_, err = applyFilters("", filters)
+	}
+
+	return err == nil
+}
+
+// applyFilters runs the value through each filter of the pipeline in order.  Each filter has the form "name" or "name:arg1:arg2".
+func applyFilters(value string, pipeline []string) (string, error) {
+	for _, f := range pipeline {
+		parts := strings.Split(f, ":")
+		name := strings.ToLower(strings.TrimSpace(parts[0]))
+		args := parts[1:]
+
+		filter, exists := tokenFilters[name]
+
+		if !exists {
+			return value, fmt.Errorf("unknown filter %q", name)
+		}
+
+		if len(args) < filter.minArgs || len(args) > filter.maxArgs {
+			return value, fmt.Errorf("filter %q expects %s, got %d", name, argCount(filter), len(args))
+		}
+
+		var err error
+		value, err = filter.apply(value, args)
+
+		if err != nil {
+			return value, fmt.Errorf("filter %q: %v", name, err)
+		}
+	}
+
+	return value, nil
+}
+
+func argCount(filter tokenFilter) string {
+	if filter.minArgs == filter.maxArgs {
+		return fmt.Sprintf("%d argument(s)", filter.minArgs)
+	}
+	return fmt.Sprintf("%d to %d arguments", filter.minArgs, filter.maxArgs)
+}
+
+func filterLength(arg string) (int, error) {
+	n, err := strconv.Atoi(arg)
+
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid length %q", arg)
+	}
+
+	return n, nil
+}
+
+func firstFilter(value string, args []string) (string, error) {
+	n, err := filterLength(args[0])
+	if err != nil {
+		return value, err
+	}
+	if len(value) > n {
+		return value[:n], nil
+	}
+	return value, nil
+}
@@ -0,0 +1,161 @@
+package functions
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const defaultHashLength = 6
+
+var hashToken = regexp.MustCompile(`^HASH(?:\(([^)]*)\))?(?::(\d+))?(?::(\w+))?$`)
+
+// hashAlphabets are the characters which can be used to encode a hash token.
+var hashAlphabets = map[string]string{
+	"base36": "0123456789abcdefghijklmnopqrstuvwxyz",
+	"hex":    "0123456789abcdef",
+	"alpha":  "abcdefghijklmnopqrstuvwxyz",
+}
+
+// computeHash computes the value of a hash token like "HASH:6", "HASH(SUBSCRIPTION,ENV):4" or "HASH(SUBSCRIPTION):8:hex".  If no variables are
+// listed, the variables referenced by the other tokens of the format (formatRefs) are used, so variables which do not appear in the name
+// do not change it.  The result is deterministic so it can be computed at plan time as long as the variables are known.
+func computeHash(token string, formatRefs []string, variables map[string]types.String, variableMaps map[string](map[string]types.String)) (types.String, error) {
+	refs, length, alphabet, err := parseHashToken(token)
+
+	if err != nil {
+		return types.StringNull(), err
+	}
+
+	var input []string
+
+	if strings.TrimSpace(refs) == "" {
+		for _, ref := range formatRefs {
+			v, err := resolveVariable(ref, variables, variableMaps)
+
+			if err != nil {
+				// the token itself reports the error or uses its fallback
+				continue
+			}
+
+			if v.IsUnknown() {
+				return v, nil
+			}
+
+			input = append(input, fmt.Sprintf("%s=%s", ref, v.ValueString()))
+		}
+	} else {
+		var hashErr error
+		unknown := false
+
+		// report every variable which cannot be resolved, not just the first
+		for _, ref := range strings.Split(refs, ",") {
+			v, err := resolveVariable(ref, variables, variableMaps)
+
+			if err != nil {
+				if hashErr == nil {
+					hashErr = fmt.Errorf("Cannot compute %q: %w", token, err)
+				} else {
+					hashErr = fmt.Errorf("%w; %v", hashErr, err)
+				}
+				continue
+			}
+
+			if v.IsUnknown() {
+				unknown = true
+				continue
+			}
+
+			input = append(input, v.ValueString())
+		}
+
+		if hashErr != nil {
+			return types.StringNull(), hashErr
+		}
+
+		if unknown {
+			return types.StringUnknown(), nil
+		}
+	}
+
+	return types.StringValue(encodeHash(strings.Join(input, "\n"), alphabet, length)), nil
+}
+
+// formatReferences returns the variable references (e.g. "APP" or "LOCS[LOC]") of the tokens of a format, without the slug and hashes.  The
+// references are uppercased, sorted and without duplicates.
+func formatReferences(format string) []string {
+	seen := make(map[string]bool)
+	var refs []string
+
+	for _, token := range formatToken.FindAllString(format, -1) {
+		head := strings.TrimSpace(strings.Split(token[2:len(token)-1], "|")[0])
+
+		if head == "" {
+			continue
+		}
+
+		head, _, _ = preprocessToken(head)
+		head, _, _ = variableFallback(head)
+		head = strings.ToUpper(strings.TrimSpace(head))
+
+		if head == "SLUG" || hashToken.MatchString(head) || seen[head] {
+			continue
+		}
+
+		seen[head] = true
+		refs = append(refs, head)
+	}
+
+	sort.Strings(refs)
+
+	return refs
+}
+
+// parseHashToken returns the variable references, length and alphabet of a hash token.
+func parseHashToken(token string) (refs string, length int, alphabet string, err error) {
+	matches := hashToken.FindStringSubmatch(token)
+
+	length = defaultHashLength
+	if matches[2] != "" {
+		length, _ = strconv.Atoi(matches[2])
+	}
+
+	alphabetName := "base36"
+	if matches[3] != "" {
+		alphabetName = strings.ToLower(matches[3])
+	}
+
+	alphabet, exists := hashAlphabets[alphabetName]
+
+	if !exists {
+		return "", 0, "", fmt.Errorf("Unknown hash alphabet %q in %q, expected one of base36, hex or alpha", matches[3], token)
+	}
+
+	if length < 1 || length > 32 {
+		return "", 0, "", fmt.Errorf("Invalid hash length %d in %q, must be between 1 and 32", length, token)
+	}
+
+	return matches[1], length, alphabet, nil
+}
+
+func encodeHash(input string, alphabet string, length int) string {
+	sum := sha256.Sum256([]byte(input))
+	n := new(big.Int).SetBytes(sum[:])
+	base := big.NewInt(int64(len(alphabet)))
+	digit := new(big.Int)
+
+	var sb strings.Builder
+
+	for i := 0; i < length; i++ {
+		n.DivMod(n, base, digit)
+		sb.WriteByte(alphabet[digit.Int64()])
+	}
+
+	return sb.String()
+}
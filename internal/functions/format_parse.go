@@ -0,0 +1,232 @@
+package functions
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// parsedToken is a token of a format which can be recovered from a name.
+type parsedToken struct {
+	group   string
+	ref     string
+	filters []string
+}
+
+// formatPattern builds a regular expression which matches the names the format (without conditional segments) can produce.  Variables
+// match any text, variable map lookups only match the (filtered) values of the map, SLUG matches the slug of the type and HASH matches
+// any hash of the right length.
+func formatPattern(format string, typeInfo typeFields, variableMaps map[string](map[string]types.String)) (*regexp.Regexp, []parsedToken, error) {
+	var sb strings.Builder
+	var tokens []parsedToken
+
+	if typeInfo.Lowercase {
+		sb.WriteString("(?i)")
+	}
+
+	sb.WriteString("^")
+	last := 0
+
+	for i, loc := range formatToken.FindAllStringIndex(format, -1) {
+		sb.WriteString(regexp.QuoteMeta(format[last:loc[0]]))
+		last = loc[1]
+
+		fullToken := format[loc[0]:loc[1]]
+		pipeline := tokenPipeline(fullToken[2 : len(fullToken)-1])
+
+		if strings.TrimSpace(pipeline[0]) == "" {
+			return nil, nil, fmt.Errorf("No variable in token %q", fullToken)
+		}
+
+		token, prefixDash, postfixDash := preprocessToken(strings.TrimSpace(pipeline[0]))
+		token, _, hasFallback := variableFallback(token)
+		token = strings.TrimSpace(token)
+
+		filters, _, err := extractShrink(pipeline[1:])
+
+		if err == nil {
+			_, err = applyFilters("", filters)
+		}
+
+		if err != nil {
+			return nil, nil, fmt.Errorf("Invalid token %q: %v", fullToken, err)
+		}
+
+		var pattern string
+		optional := hasFallback || prefixDash || postfixDash
+		parsed := parsedToken{group: fmt.Sprintf("t%d", i), ref: token, filters: filters}
+
+		if token == "SLUG" {
+			slug, _ := applyFilters(typeInfo.Slug, filters)
+			pattern = regexp.QuoteMeta(slug)
+			parsed.ref = ""
+		} else if hashToken.MatchString(token) {
+			_, length, alphabet, err := parseHashToken(token)
+
+			if err != nil {
+				return nil, nil, err
+			}
+
+			pattern = fmt.Sprintf("[%s]{%d}", regexp.QuoteMeta(alphabet), length)
+			parsed.ref = ""
+		} else if mapName, _, isLookup := splitLookup(token); isLookup {
+			vm, exists := variableMaps[strings.ToUpper(mapName)]
+
+			if !exists {
+				return nil, nil, fmt.Errorf("No variable map found for %q", mapName)
+			}
+
+			pattern = alternation(vm, filters)
+		} else {
+			pattern = ".+?"
+		}
+
+		if pattern == "" {
+			optional = true
+		}
+
+		group := fmt.Sprintf("(?P<%s>%s)", parsed.group, pattern)
+
+		if prefixDash {
+			group = "-" + group
+		} else if postfixDash {
+			group = group + "-"
+		}
+
+		if optional {
+			group = "(?:" + group + ")?"
+		}
+
+		sb.WriteString(group)
+		tokens = append(tokens, parsed)
+	}
+
+	sb.WriteString(regexp.QuoteMeta(format[last:]))
+	sb.WriteString("$")
+
+	re, err := regexp.Compile(sb.String())
+
+	return re, tokens, err
+}
+
+// alternation returns a pattern matching any of the filtered values of the variable map, longest values first.
+func alternation(vm map[string]types.String, filters []string) string {
+	var values []string
+
+	for _, v := range vm {
+		if v.IsUnknown() || v.IsNull() {
+			continue
+		}
+
+		filtered, _ := applyFilters(v.ValueString(), filters)
+		values = append(values, regexp.QuoteMeta(filtered))
+	}
+
+	if len(values) == 0 {
+		// a character class which cannot match anything
+		return `[^\s\S]`
+	}
+
+	sort.Slice(values, func(a, b int) bool {
+		if len(values[a]) != len(values[b]) {
+			return len(values[a]) > len(values[b])
+		}
+		return values[a] < values[b]
+	})
+
+	return strings.Join(values, "|")
+}
+
+// parseName recovers the values of the variables used by the format from a name.
+func parseName(name string, format string, typeInfo typeFields, variableMaps map[string](map[string]types.String), variableMapKeys map[string](map[string]string)) (map[string]string, error) {
+	re, tokens, err := formatPattern(format, typeInfo, variableMaps)
+
+	if err != nil {
+		return nil, err
+	}
+
+	matches := re.FindStringSubmatch(name)
+
+	if matches == nil {
+		return nil, fmt.Errorf("Name %q does not match the format %q", name, format)
+	}
+
+	result := make(map[string]string)
+
+	for _, t := range tokens {
+		value := matches[re.SubexpIndex(t.group)]
+
+		if t.ref == "" || value == "" {
+			continue
+		}
+
+		values, err := invertReference(t.ref, value, t.filters, variableMaps, variableMapKeys)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for k, v := range values {
+			if existing, exists := result[k]; exists && existing != v {
+				return nil, fmt.Errorf("Conflicting values %q and %q found for %q in name %q", existing, v, k, name)
+			}
+
+			result[k] = v
+		}
+	}
+
+	return result, nil
+}
+
+// invertReference returns the variables which resolve the reference to the value, following variable map lookups backwards.  When several
+// keys of a map have the same value, the first key (in sorted order) is used.  A lookup with a literal key does not reference a variable,
+// so it sets none.  A value which only matches the default entry of a map cannot be inverted and is an error.
+func invertReference(ref string, value string, filters []string, variableMaps map[string](map[string]types.String), variableMapKeys map[string](map[string]string)) (map[string]string, error) {
+	mapName, key, isLookup := splitLookup(ref)
+
+	if !isLookup {
+		if !variableName.MatchString(ref) {
+			return nil, fmt.Errorf("Invalid variable reference %q", ref)
+		}
+
+		return map[string]string{strings.ToUpper(ref): value}, nil
+	}
+
+	if _, isLiteral := unquoteLiteral(key); isLiteral {
+		return map[string]string{}, nil
+	}
+
+	vm := variableMaps[strings.ToUpper(mapName)]
+	keys := make([]string, 0, len(vm))
+
+	for k, v := range vm {
+		if k == defaultMapEntry || v.IsUnknown() || v.IsNull() {
+			continue
+		}
+
+		if filtered, _ := applyFilters(v.ValueString(), filters); strings.EqualFold(filtered, value) {
+			keys = append(keys, k)
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("Cannot recover %q from %q, it only matches the default entry of the variable map %q", key, value, mapName)
+	}
+
+	sort.Strings(keys)
+
+	var err error
+
+	for _, k := range keys {
+		var values map[string]string
+
+		if values, err = invertReference(key, variableMapKeys[strings.ToUpper(mapName)][k], nil, variableMaps, variableMapKeys); err == nil {
+			return values, nil
+		}
+	}
+
+	return nil, err
+}
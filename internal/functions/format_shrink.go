@@ -0,0 +1,116 @@
+package functions
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var shrinkPlaceholder = regexp.MustCompile("\x00(\\d+)\x00")
+
+type shrinkSpec struct {
+	priority   int
+	min        int
+	hashLength int
+}
+
+type shrinkPart struct {
+	spec  shrinkSpec
+	value string
+}
+
+// extractShrink removes the "shrink:PRIORITY[:MIN[:HASH]]" marker from a token pipeline.  Unlike the other filters it does not change the
+// value of the token, instead it marks the value as one that can be shortened if the name is too long.
+func extractShrink(pipeline []string) ([]string, *shrinkSpec, error) {
+	var spec *shrinkSpec
+	result := make([]string, 0, len(pipeline))
+
+	for _, f := range pipeline {
+		parts := strings.Split(f, ":")
+
+		if strings.ToLower(strings.TrimSpace(parts[0])) != "shrink" {
+			result = append(result, f)
+			continue
+		}
+
+		if spec != nil {
+			return pipeline, nil, fmt.Errorf("filter %q can only be used once", "shrink")
+		}
+
+		args := parts[1:]
+
+		if len(args) < 1 || len(args) > 3 {
+			return pipeline, nil, fmt.Errorf("filter %q expects 1 to 3 arguments, got %d", "shrink", len(args))
+		}
+
+		spec = &shrinkSpec{min: 1}
+		values := []*int{&spec.priority, &spec.min, &spec.hashLength}
+
+		for i, arg := range args {
+			n, err := strconv.Atoi(arg)
+
+			if err != nil {
+				return pipeline, nil, fmt.Errorf("filter %q: invalid number %q", "shrink", arg)
+			}
+
+			*values[i] = n
+		}
+
+		if spec.min < 1 || spec.hashLength < 0 || spec.hashLength > 32 {
+			return pipeline, nil, fmt.Errorf("filter %q: minimum must be at least 1 and the hash length between 0 and 32", "shrink")
+		}
+	}
+
+	return result, spec, nil
+}
+
+// shrinkName replaces the placeholders in the result with the values of the shrinkable parts.  If the name would be longer than maxLength,
+// the parts are shortened first, starting with the lowest priority, until the name fits (or none of the parts can be shortened any further).
+// A part which is shortened can end with a hash of the text which was removed so that the name stays unique.
+func shrinkName(result string, parts []shrinkPart, maxLength int) string {
+	if len(parts) == 0 {
+		return result
+	}
+
+	length := len(shrinkPlaceholder.ReplaceAllString(result, ""))
+	for _, p := range parts {
+		length += len(p.value)
+	}
+
+	excess := length - maxLength
+
+	if maxLength > 0 && excess > 0 {
+		order := make([]int, len(parts))
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(a, b int) bool {
+			return parts[order[a]].spec.priority < parts[order[b]].spec.priority
+		})
+
+		for _, i := range order {
+			if excess <= 0 {
+				break
+			}
+
+			p := &parts[i]
+			minimum := max(p.spec.min, p.spec.hashLength+1)
+			cut := min(excess, len(p.value)-minimum)
+
+			if cut <= 0 {
+				continue
+			}
+
+			keep := len(p.value) - cut - p.spec.hashLength
+			p.value = p.value[:keep] + encodeHash(p.value[keep:], hashAlphabets["base36"], p.spec.hashLength)
+			excess -= cut
+		}
+	}
+
+	return shrinkPlaceholder.ReplaceAllStringFunc(result, func(placeholder string) string {
+		i, _ := strconv.Atoi(placeholder[1 : len(placeholder)-1])
+		return parts[i].value
+	})
+}
@@ -0,0 +1,73 @@
+package functions
+
+import (
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// The sources of the value of a token.
+const (
+	sourceSlug        = "SLUG"
+	sourceHash        = "HASH"
+	sourceVariable    = "variable"
+	sourceOverride    = "override"
+	sourceVariableMap = "variable_map"
+	sourceFallback    = "fallback"
+)
+
+// nameTrace records how a name was generated.  All methods can be called on a nil trace, in which case nothing is recorded.
+type nameTrace struct {
+	candidates []string
+	selector   string
+	format     types.String
+	typeInfo   typeFields
+	tokens     []tokenTrace
+	overridden map[string]bool
+}
+
+type tokenTrace struct {
+	token  string
+	value  types.String
+	source string
+}
+
+func newNameTrace() *nameTrace {
+	return &nameTrace{
+		format:     types.StringNull(),
+		overridden: make(map[string]bool),
+	}
+}
+
+// source returns where the value of a token (without filters and fallback) comes from.
+func (t *nameTrace) source(token string) string {
+	if t == nil {
+		return ""
+	}
+
+	if token == "SLUG" {
+		return sourceSlug
+	}
+
+	if hashToken.MatchString(token) {
+		return sourceHash
+	}
+
+	if _, _, isLookup := splitLookup(token); isLookup {
+		return sourceVariableMap
+	}
+
+	if t.overridden[strings.ToUpper(strings.TrimSpace(token))] {
+		return sourceOverride
+	}
+
+	return sourceVariable
+}
+
+func (t *nameTrace) addToken(token string, value types.String, source string) {
+	if t == nil {
+		return
+	}
+
+	t.tokens = append(t.tokens, tokenTrace{token: token, value: value, source: source})
+}
@@ -0,0 +1,170 @@
+package functions
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the desired interfaces.
+var _ function.Function = &ExplainNameFunction{}
+
+var tokenTraceAttributeTypes = map[string]attr.Type{
+	"token":  types.StringType,
+	"value":  types.StringType,
+	"source": types.StringType,
+}
+
+var rulesAttributeTypes = map[string]attr.Type{
+	"min_length":       types.Int64Type,
+	"max_length":       types.Int64Type,
+	"lowercase":        types.BoolType,
+	"validation_regex": types.StringType,
+	"cleanup_regex":    types.StringType,
+}
+
+var explanationAttributeTypes = map[string]attr.Type{
+	"name":       types.StringType,
+	"valid":      types.BoolType,
+	"errors":     types.ListType{ElemType: types.StringType},
+	"candidates": types.ListType{ElemType: types.StringType},
+	"selector":   types.StringType,
+	"format":     types.StringType,
+	"tokens":     types.ListType{ElemType: types.ObjectType{AttrTypes: tokenTraceAttributeTypes}},
+	"rules":      types.ObjectType{AttrTypes: rulesAttributeTypes},
+}
+
+func NewExplainNameFunction() function.Function {
+	return &ExplainNameFunction{}
+}
+
+type ExplainNameFunction struct{}
+
+func (f *ExplainNameFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "explain_name"
+}
+
+func (f *ExplainNameFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Explain how a name string is generated",
+		Description: `This function takes the same arguments as ` + "`namestring`" + ` and returns how the name was generated: the format selectors which were tried, the
+					  format which was selected, the value and source of every token and the validation rules of the resource type.  Errors are reported in the result instead of failing.`,
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "resource_type",
+				Description: "Type of resource to create a name for (required for selecting format, certain variables and perform validation)",
+			},
+			configurationParameter("configurations"),
+		},
+		VariadicParameter: function.MapParameter{
+			Name:        "overrides",
+			Description: "Variable overrides.  Each argument will be processed in order, overriding the `variables` map which was passed in the configuration parameter.",
+			ElementType: types.StringType,
+		},
+
+		Return: function.ObjectReturn{AttributeTypes: explanationAttributeTypes},
+	}
+}
+
+func (f *ExplainNameFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var resourceType string
+	var configurationsObj types.Object
+	var overridesArg []map[string]string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &resourceType, &configurationsObj, &overridesArg))
+
+	if resp.Error != nil {
+		return
+	}
+
+	config, known, funcErr := newNameConfiguration(ctx, configurationsObj, argumentPositions{resourceType: 0, configuration: 1, overrides: 2})
+	resp.Error = function.ConcatFuncErrors(resp.Error, funcErr)
+
+	if resp.Error != nil || !known {
+		return
+	}
+
+	trace := newNameTrace()
+	name, funcErr := config.generateName(ctx, resourceType, overridesArg, trace)
+
+	if funcErr == nil && trace.candidates == nil {
+		// the type of the resource is unknown, so nothing can be explained yet
+		return
+	}
+
+	var errors []attr.Value
+	valid := types.BoolValue(funcErr == nil)
+
+	if funcErr == nil && name.IsUnknown() {
+		valid = types.BoolUnknown()
+	}
+
+	if funcErr != nil {
+		if name.IsUnknown() {
+			name = types.StringNull()
+		}
+
+		for _, text := range strings.Split(funcErr.Text, "\n") {
+			errors = append(errors, types.StringValue(text))
+		}
+	}
+
+	values := map[string]attr.Value{
+		"name":       name,
+		"valid":      valid,
+		"errors":     listValue(types.StringType, errors),
+		"candidates": listValue(types.StringType, stringValues(trace.candidates)),
+		"selector":   types.StringNull(),
+		"format":     trace.format,
+		"tokens":     listValue(types.ObjectType{AttrTypes: tokenTraceAttributeTypes}, tokenValues(trace.tokens)),
+		"rules": types.ObjectValueMust(rulesAttributeTypes, map[string]attr.Value{
+			"min_length":       types.Int64Value(int64(trace.typeInfo.MinLength)),
+			"max_length":       types.Int64Value(int64(trace.typeInfo.MaxLength)),
+			"lowercase":        types.BoolValue(trace.typeInfo.Lowercase),
+			"validation_regex": types.StringValue(trace.typeInfo.ValidatationRegex),
+			"cleanup_regex":    types.StringValue(trace.typeInfo.CleanupRegex),
+		}),
+	}
+
+	if trace.selector != "" {
+		values["selector"] = types.StringValue(trace.selector)
+	}
+
+	resp.Error = resp.Result.Set(ctx, types.ObjectValueMust(explanationAttributeTypes, values))
+}
+
+func listValue(elemType attr.Type, elems []attr.Value) types.List {
+	if elems == nil {
+		elems = []attr.Value{}
+	}
+
+	return types.ListValueMust(elemType, elems)
+}
+
+func stringValues(values []string) []attr.Value {
+	result := make([]attr.Value, 0, len(values))
+
+	for _, v := range values {
+		result = append(result, types.StringValue(v))
+	}
+
+	return result
+}
+
+func tokenValues(tokens []tokenTrace) []attr.Value {
+	result := make([]attr.Value, 0, len(tokens))
+
+	for _, t := range tokens {
+		result = append(result, types.ObjectValueMust(tokenTraceAttributeTypes, map[string]attr.Value{
+			"token":  types.StringValue(t.token),
+			"value":  t.value,
+			"source": types.StringValue(t.source),
+		}))
+	}
+
+	return result
+}
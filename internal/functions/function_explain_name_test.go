@@ -0,0 +1,87 @@
+package functions_test
+
+import (
+	"fmt"
+	"regexp"
+	"terraform-provider-namep/internal/provider"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestExplainNameFunction_Tokens(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"namep": providerserver.NewProtocol6WithError(provider.New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf("%s %s", config_with_default_format_fmt, `output "test" {
+					value = provider::namep::explain_name("azurerm_resource_group", local.config, { name = "mygroup" })
+				}`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValueAtPath("test", tfjsonpath.New("name"), knownvalue.StringExact("rg-myapp-dev-weu-mygroup-uxx1")),
+					statecheck.ExpectKnownOutputValueAtPath("test", tfjsonpath.New("valid"), knownvalue.Bool(true)),
+					statecheck.ExpectKnownOutputValueAtPath("test", tfjsonpath.New("selector"), knownvalue.StringExact("azure_dashes_global")),
+					statecheck.ExpectKnownOutputValueAtPath("test", tfjsonpath.New("candidates"), knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.StringExact("azurerm_resource_group"),
+						knownvalue.StringExact("azure_dashes_global"),
+						knownvalue.StringExact("azure_dashes"),
+						knownvalue.StringExact("azure"),
+					})),
+					statecheck.ExpectKnownOutputValueAtPath("test", tfjsonpath.New("tokens").AtSliceIndex(0).AtMapKey("source"), knownvalue.StringExact("SLUG")),
+					statecheck.ExpectKnownOutputValueAtPath("test", tfjsonpath.New("tokens").AtSliceIndex(3), knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"token":  knownvalue.StringExact("#{LOCS[LOC]}"),
+						"value":  knownvalue.StringExact("weu"),
+						"source": knownvalue.StringExact("variable_map"),
+					})),
+					statecheck.ExpectKnownOutputValueAtPath("test", tfjsonpath.New("tokens").AtSliceIndex(4).AtMapKey("source"), knownvalue.StringExact("override")),
+				},
+			},
+		},
+	})
+}
+
+func TestExplainNameFunction_Invalid(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"namep": providerserver.NewProtocol6WithError(provider.New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf("%s %s", config_with_default_format_fmt, `output "test" {
+					value = provider::namep::explain_name("too_long", local.config)
+				}`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValueAtPath("test", tfjsonpath.New("valid"), knownvalue.Bool(false)),
+					statecheck.ExpectKnownOutputValueAtPath("test", tfjsonpath.New("errors"), knownvalue.ListSizeExact(2)),
+					statecheck.ExpectKnownOutputValueAtPath("test", tfjsonpath.New("rules").AtMapKey("max_length"), knownvalue.Int64Exact(2)),
+				},
+			},
+		},
+	})
+}
+
+func TestExplainNameFunction_InvalidValidationRegex(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"namep": providerserver.NewProtocol6WithError(provider.New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf("%s %s %s", config_with_default_format_fmt, invalid_validation_regex_config, `output "test" {
+					value = provider::namep::explain_name("bad_regex", local.bad_regex_config, { name = "main" })
+				}`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValueAtPath("test", tfjsonpath.New("valid"), knownvalue.Bool(false)),
+					statecheck.ExpectKnownOutputValueAtPath("test", tfjsonpath.New("errors").AtSliceIndex(0), knownvalue.StringRegexp(regexp.MustCompile(`^Invalid validation regex "\[a-"`))),
+				},
+			},
+		},
+	})
+}
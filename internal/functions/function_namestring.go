@@ -2,36 +2,30 @@ package functions
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
+	"unicode/utf8"
 
-	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
-	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the desired interfaces.
 var _ function.Function = &NameStringFunction{}
 
+var variableName = regexp.MustCompile(`^\w+$`)
+
+// defaultMapEntry is the key of the entry used when a variable map does not contain the key being looked up.
+const defaultMapEntry = "*"
+
 func NewNameStringFunction() function.Function {
 	return &NameStringFunction{}
 }
 
 type NameStringFunction struct{}
 
-type typeFields struct {
-	Name              string `tfsdk:"name"`
-	Slug              string `tfsdk:"slug"`
-	MinLength         int    `tfsdk:"min_length"`
-	MaxLength         int    `tfsdk:"max_length"`
-	Lowercase         bool   `tfsdk:"lowercase"`
-	ValidatationRegex string `tfsdk:"validation_regex"`
-	DefaultSelector   string `tfsdk:"default_selector"`
-}
-
 func (f *NameStringFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
 	resp.Name = "namestring"
 }
@@ -47,29 +41,7 @@ func (f *NameStringFunction) Definition(ctx context.Context, req function.Defini
 				Name:        "resource_type",
 				Description: "Type of resource to create a name for (required for selecting format, certain variables and perform validation)",
 			},
-			function.ObjectParameter{
-				Name:               "configurations",
-				Description:        "A configuration object that contains the variables and formats to use for the name.",
-				AllowUnknownValues: true,
-				AttributeTypes: map[string]attr.Type{
-					"variables":     types.MapType{ElemType: types.StringType},
-					"formats":       types.MapType{ElemType: types.StringType},
-					"variable_maps": types.MapType{ElemType: types.MapType{ElemType: types.StringType}},
-					"types": types.MapType{
-						ElemType: types.ObjectType{
-							AttrTypes: map[string]attr.Type{
-								"name":             types.StringType,
-								"slug":             types.StringType,
-								"min_length":       types.Int32Type,
-								"max_length":       types.Int32Type,
-								"lowercase":        types.BoolType,
-								"validation_regex": types.StringType,
-								"default_selector": types.StringType,
-							},
-						},
-					},
-				},
-			},
+			configurationParameter("configurations"),
 		},
 		VariadicParameter: function.MapParameter{
 			Name:        "overrides",
@@ -88,177 +60,116 @@ func (f *NameStringFunction) Run(ctx context.Context, req function.RunRequest, r
 
 	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &resourceType, &configurationsObj, &overridesArg))
 
-	if resp.Error != nil || configurationsObj.IsUnknown() {
-		return
-	}
-
-	var cfgs struct {
-		Variables    types.Map `tfsdk:"variables"`
-		Formats      types.Map `tfsdk:"formats"`
-		VariableMaps types.Map `tfsdk:"variable_maps"`
-		Types        types.Map `tfsdk:"types"`
-	}
-
-	diags := configurationsObj.As(ctx, &cfgs, basetypes.ObjectAsOptions{})
-	resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, diags))
-
-	if cfgs.Formats.IsUnknown() || cfgs.Types.IsUnknown() || cfgs.Variables.IsUnknown() || cfgs.VariableMaps.IsUnknown() {
-		// if the top level maps are unknown then skip for a later phase where at least those are known
-		return
-	}
-
-	var configurationsArg struct {
-		Variables    map[string]types.String `tfsdk:"variables"`
-		Formats      map[string]types.String `tfsdk:"formats"`
-		VariableMaps map[string]types.Map    `tfsdk:"variable_maps"`
-		Types        map[string]types.Object `tfsdk:"types"`
-	}
-
-	diags = configurationsObj.As(ctx, &configurationsArg, basetypes.ObjectAsOptions{})
-	resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, diags))
-
 	if resp.Error != nil {
 		return
 	}
 
-	typeInfo := typeFields{
-		DefaultSelector:   "custom",
-		ValidatationRegex: ".*", // No possible validation for default custom names
-	}
-	for k, o := range configurationsArg.Types {
-		if k == resourceType {
-			if o.IsUnknown() {
-				return
-			}
-			diag := o.As(ctx, &typeInfo, basetypes.ObjectAsOptions{})
-			resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, diag))
-			break
-		}
-	}
-
-	toSearch := formatSearchStrings(resourceType, typeInfo.DefaultSelector)
-	var format string
-	var formatString types.String
-	var exists bool
-
-	for _, search := range toSearch {
-		tflog.Debug(ctx, fmt.Sprintf("searching for format: %q", search))
-		formatString, exists = configurationsArg.Formats[search]
-
-		if exists {
-			if formatString.IsUnknown() {
-				return
-			}
+	config, known, funcErr := newNameConfiguration(ctx, configurationsObj, argumentPositions{resourceType: 0, configuration: 1, overrides: 2})
+	resp.Error = function.ConcatFuncErrors(resp.Error, funcErr)
 
-			format = formatString.ValueString()
-			break
-		}
-	}
-
-	if !exists {
-		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewFuncError(fmt.Sprintf("No format found for resource type %q, tried %v", resourceType, toSearch)))
+	if resp.Error != nil || !known {
 		return
 	}
 
-	variables := keysToUpper(configurationsArg.Variables)
-
-	for _, overrideValue := range overridesArg {
-		if overrideValue == nil {
-			resp.Error = function.ConcatFuncErrors(resp.Error, function.NewFuncError("Got null map for override"))
-			continue
-		}
+	result, funcErr := config.generateName(ctx, resourceType, overridesArg, nil)
+	resp.Error = function.ConcatFuncErrors(resp.Error, funcErr)
 
-		for k, v := range overrideValue {
-			variables[strings.ToUpper(k)] = types.StringValue(v)
-		}
-	}
-
-	variableMaps := make(map[string](map[string]types.String), len(configurationsArg.VariableMaps))
-
-	for k, v := range configurationsArg.VariableMaps {
-		if v.IsUnknown() {
-			return
-		}
-
-		vm := make(map[string]types.String, len(v.Elements()))
-		diags = v.ElementsAs(ctx, &vm, false)
-		resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, diags))
-
-		variableMaps[strings.ToUpper(k)] = keysToUpper(vm)
-	}
-
-	resp.Error = function.ConcatFuncErrors(resp.Error, setCalculatedName(ctx, typeInfo, format, variables, variableMaps, resp))
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, result))
 }
 
-func formatSearchStrings(resourceType string, defaultSelector string) []string {
-	var result []string
-	result = append(result, resourceType)
-	result = append(result, defaultSelector)
+// calculateName renders the format for a resource type.  The result is unknown if any variable used by the format is unknown.  All problems
+// with the tokens of the format are reported at once, each tied to the argument returned by argumentOf for the variable which caused it.  If
+// trace is not nil, the value and source of every token is recorded in it.
+func calculateName(typeInfo typeFields, formatKey string, format string, variables map[string]types.String, variableMaps map[string](map[string]types.String), argumentOf func(variable string) int64, trace *nameTrace) (types.String, *function.FuncError) {
+	var funcErr *function.FuncError
+	configuration := argumentOf("")
 
-	parts := strings.Split(defaultSelector, "_")
+	format, isUnknown, err := evaluateConditionals(format, variables, variableMaps)
 
-	for i := len(parts) - 1; i > 0; i-- {
-		result = append(result, strings.Join(parts[:i], "_"))
+	if err != nil {
+		return types.StringUnknown(), function.NewArgumentFuncError(configuration, fmt.Sprintf("Invalid format %q: %v", formatKey, err))
 	}
 
-	return result
-}
-
-func setCalculatedName(ctx context.Context, typeInfo typeFields, format string, variables map[string]types.String, variableMaps map[string](map[string]types.String), resp *function.RunResponse) *function.FuncError {
-	re := regexp.MustCompile(`#\{-?[\w[\]]+-?}`)
+	if isUnknown {
+		return types.StringUnknown(), nil
+	}
 
-	isUnknown := false
+	var shrinkParts []shrinkPart
+	formatRefs := formatReferences(format)
 
-	result := re.ReplaceAllStringFunc(format, func(token string) (r string) {
+	result := formatToken.ReplaceAllStringFunc(format, func(token string) (r string) {
 		tl := len(token)
 		if tl < 1 {
-			resp.Error = function.ConcatFuncErrors(resp.Error, function.NewFuncError(fmt.Sprintf("bizarre variable received %q", token)))
+			funcErr = function.ConcatFuncErrors(funcErr, function.NewArgumentFuncError(configuration, fmt.Sprintf("bizarre variable received %q", token)))
 			return token
 		}
 
-		token, prefixDash, postfixDash := preprocessToken(token[2 : tl-1])
+		fullToken := token
+		pipeline := tokenPipeline(token[2 : tl-1])
+
+		if strings.TrimSpace(pipeline[0]) == "" {
+			funcErr = function.ConcatFuncErrors(funcErr, function.NewArgumentFuncError(configuration, fmt.Sprintf("No variable in token %q of format %q", fullToken, formatKey)))
+			return token
+		}
+
+		token, prefixDash, postfixDash := preprocessToken(strings.TrimSpace(pipeline[0]))
+		token, fallback, hasFallback := variableFallback(token)
 		tokenProcessed := true
 		var tokenResult string
+		source := trace.source(token)
 
 		if token == "SLUG" {
 			tokenResult = typeInfo.Slug
 		} else {
-			varName, varMapName := variableLocation(token)
+			var v types.String
+			var err error
 
-			v, varExists := variables[strings.ToUpper(varName)]
+			if hashToken.MatchString(token) {
+				v, err = computeHash(token, formatRefs, variables, variableMaps)
+			} else {
+				v, err = resolveVariable(token, variables, variableMaps)
+			}
 
-			if !varExists {
-				resp.Error = function.ConcatFuncErrors(resp.Error, function.NewFuncError(fmt.Sprintf("No variable found for %q", varName)))
-				return token
+			if err != nil {
+				if !hasFallback {
+					funcErr = function.ConcatFuncErrors(funcErr, function.NewArgumentFuncError(argumentOf(blamedVariable(err)), fmt.Sprintf("%v (token %q in format %q)", err, fullToken, formatKey)))
+					trace.addToken(fullToken, types.StringNull(), source)
+					return token
+				}
+
+				v = types.StringValue(fallback)
+				source = sourceFallback
 			}
 
 			if v.IsUnknown() {
 				isUnknown = true
 				tokenProcessed = false
+				trace.addToken(fullToken, v, source)
 				return token
 			}
 
-			val := v.ValueString()
+			tokenResult = v.ValueString()
+		}
 
-			if varMapName != "" {
-				vm, mapExists := variableMaps[varMapName]
+		if tokenProcessed {
+			filters, shrink, err := extractShrink(pipeline[1:])
 
-				if !mapExists {
-					resp.Error = function.ConcatFuncErrors(resp.Error, function.NewFuncError(fmt.Sprintf("No variable map found for %q", varMapName)))
-					return token
-				}
+			if err == nil {
+				tokenResult, err = applyFilters(tokenResult, filters)
+			}
 
-				v, varExists = vm[strings.ToUpper(val)]
+			if err != nil {
+				funcErr = function.ConcatFuncErrors(funcErr, function.NewArgumentFuncError(configuration, fmt.Sprintf("Invalid token %q in format %q: %v", fullToken, formatKey, err)))
+				trace.addToken(fullToken, types.StringNull(), source)
+				return fullToken
+			}
 
-				if !varExists {
-					resp.Error = function.ConcatFuncErrors(resp.Error, function.NewFuncError(fmt.Sprintf("No variable found for value %q (value of %q) in map %q", val, varName, varMapName)))
-					return token
-				}
+			trace.addToken(fullToken, types.StringValue(tokenResult), source)
 
-				val = v.ValueString()
+			if shrink != nil && len(tokenResult) > 0 {
+				shrinkParts = append(shrinkParts, shrinkPart{spec: *shrink, value: tokenResult})
+				tokenResult = fmt.Sprintf("\x00%d\x00", len(shrinkParts)-1)
 			}
-
-			tokenResult = val
 		}
 
 		if tokenProcessed && len(tokenResult) > 0 {
@@ -272,23 +183,35 @@ func setCalculatedName(ctx context.Context, typeInfo typeFields, format string,
 		return tokenResult
 	})
 
-	if isUnknown {
-		return function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, types.StringUnknown()))
+	if isUnknown || funcErr != nil {
+		return types.StringUnknown(), funcErr
 	}
 
-	resp.Error = validateResult(result, typeInfo, resp)
+	result = shrinkName(result, shrinkParts, typeInfo.MaxLength)
 
-	return function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, result))
-}
+	if typeInfo.CleanupRegex != "" {
+		result, err = sanitizeResult(result, typeInfo)
 
-func keysToUpper(m map[string]types.String) map[string]types.String {
-	newMap := make(map[string]types.String, len(m))
-	for k, v := range m {
-		newMap[strings.ToUpper(k)] = v
+		if err != nil {
+			return types.StringUnknown(), function.ConcatFuncErrors(funcErr, function.NewArgumentFuncError(configuration, fmt.Sprintf("Invalid cleanup regex %q: %v", typeInfo.CleanupRegex, err)))
+		}
 	}
-	return newMap
+
+	failures, err := validationFailures(result, typeInfo)
+
+	if err != nil {
+		return types.StringUnknown(), function.ConcatFuncErrors(funcErr, function.NewArgumentFuncError(configuration, fmt.Sprintf("Invalid validation regex %q: %v", typeInfo.ValidatationRegex, err)))
+	}
+
+	for _, failure := range failures {
+		funcErr = function.ConcatFuncErrors(funcErr, function.NewArgumentFuncError(configuration, fmt.Sprintf("%s (format %q)", failure, formatKey)))
+	}
+
+	return types.StringValue(result), funcErr
 }
 
+// preprocessToken strips the optional dash from a token.  A trailing dash which belongs to an empty default (e.g. "SALT:-") is
+// left for variableFallback.
 func preprocessToken(token string) (result string, pre bool, post bool) {
 	pre = false
 	post = false
@@ -298,40 +221,218 @@ func preprocessToken(token string) (result string, pre bool, post bool) {
 	if token[0] == '-' {
 		pre = true
 		result = token[1:]
-	} else if token[l-1] == '-' {
+	} else if token[l-1] == '-' && !strings.HasSuffix(token, ":-") {
 		post = true
-		result = token[0 : l-2]
+		result = token[0 : l-1]
 	}
 
 	return result, pre, post
 }
 
-func variableLocation(token string) (varName string, varMapName string) {
-	re := regexp.MustCompile(`(\w+)\[(\w+)]`)
+// variableFallback splits the fallback from a token: "VAR:-default" falls back to "default" and "VAR?" falls back to an empty string
+// when the variable cannot be resolved.
+func variableFallback(token string) (result string, fallback string, hasFallback bool) {
+	if i := strings.Index(token, ":-"); i >= 0 {
+		return token[:i], token[i+2:], true
+	}
+
+	if strings.HasSuffix(token, "?") {
+		return token[:len(token)-1], "", true
+	}
+
+	return token, "", false
+}
+
+func resolveVariable(token string, variables map[string]types.String, variableMaps map[string](map[string]types.String)) (types.String, error) {
+	v, _, err := resolveReference(strings.TrimSpace(token), variables, variableMaps)
+	return v, err
+}
 
-	matches := re.FindAllStringSubmatch(token, -1)
+// resolveReference resolves a variable (e.g. "LOC") or a variable map lookup, which can be nested to any depth (e.g. "LOCS[LOCS_FROM_DISPLAY_NAME[LOC]]").
+// The chain of resolution steps is returned so errors can show how the value was looked up.
+func resolveReference(ref string, variables map[string]types.String, variableMaps map[string](map[string]types.String)) (types.String, []string, error) {
+	mapName, key, isLookup := splitLookup(ref)
 
-	if matches != nil {
-		return matches[0][2], matches[0][1]
+	if !isLookup {
+		if !variableName.MatchString(ref) {
+			return types.StringNull(), nil, fmt.Errorf("Invalid variable reference %q", ref)
+		}
+
+		v, varExists := variables[strings.ToUpper(ref)]
+
+		if !varExists {
+			return v, nil, &resolutionError{message: fmt.Sprintf("No variable found for %q", ref)}
+		}
+
+		if v.IsUnknown() {
+			return v, nil, nil
+		}
+
+		return v, []string{fmt.Sprintf("%s = %q", ref, v.ValueString())}, nil
+	}
+
+	if !variableName.MatchString(mapName) {
+		return types.StringNull(), nil, fmt.Errorf("Invalid variable map name %q in %q", mapName, ref)
+	}
+
+	var k types.String
+	var chain []string
+
+	if literal, isLiteral := unquoteLiteral(key); isLiteral {
+		k = types.StringValue(literal)
+	} else {
+		var err error
+		k, chain, err = resolveReference(key, variables, variableMaps)
+
+		if err != nil || k.IsUnknown() {
+			return k, chain, err
+		}
+	}
+
+	val := k.ValueString()
+	vm, mapExists := variableMaps[strings.ToUpper(mapName)]
+
+	if !mapExists {
+		return k, chain, &resolutionError{message: fmt.Sprintf("No variable map found for %q", mapName), chain: chain}
+	}
+
+	step := fmt.Sprintf("%s[%q]", mapName, val)
+	v, varExists := vm[strings.ToUpper(val)]
+
+	if !varExists {
+		v, varExists = vm[defaultMapEntry]
+		step = fmt.Sprintf("%s[%q]", mapName, defaultMapEntry)
+	}
+
+	if !varExists {
+		return v, chain, &resolutionError{message: fmt.Sprintf("No variable found for value %q (value of %q) in map %q", val, key, mapName), chain: chain, variable: rootVariable(key)}
+	}
+
+	if v.IsUnknown() {
+		return v, chain, nil
 	}
 
-	return token, ""
+	return v, append(chain, fmt.Sprintf("%s = %q", step, v.ValueString())), nil
 }
 
-func validateResult(result string, typeInfo typeFields, resp *function.RunResponse) *function.FuncError {
-	re := regexp.MustCompile(typeInfo.ValidatationRegex)
+// unquoteLiteral returns the literal value of a string quoted with either double or single quotes (e.g. "westeurope" or 'westeurope').
+func unquoteLiteral(s string) (string, bool) {
+	if l := len(s); l >= 2 && (s[0] == '"' || s[0] == '\'') && s[l-1] == s[0] {
+		return s[1 : l-1], true
+	}
 
-	if !re.MatchString(result) {
-		if len(result) > typeInfo.MaxLength {
-			resp.Error = function.ConcatFuncErrors(resp.Error, function.NewFuncError(fmt.Sprintf("resulting name is too long (%d > %d): %s", len(result), typeInfo.MaxLength, result)))
-		} else if typeInfo.Lowercase && strings.ToLower(result) != result {
-			resp.Error = function.ConcatFuncErrors(resp.Error, function.NewFuncError(fmt.Sprintf("resulting name must be lowercase: %s", result)))
-		} else if len(result) < typeInfo.MinLength {
-			resp.Error = function.ConcatFuncErrors(resp.Error, function.NewFuncError(fmt.Sprintf("resulting name is too short (%d < %d): %s", len(result), typeInfo.MinLength, result)))
-		} else {
-			resp.Error = function.ConcatFuncErrors(resp.Error, function.NewFuncError(fmt.Sprintf("Resulting name does not match the validation regex (validation regex: %s): %q", typeInfo.ValidatationRegex, result)))
+	return s, false
+}
+
+// splitLookup splits a map lookup like "LOCS[LOC]" into the map name and the key reference.
+func splitLookup(ref string) (mapName string, key string, isLookup bool) {
+	i := strings.Index(ref, "[")
+
+	if i < 0 || !strings.HasSuffix(ref, "]") {
+		return ref, "", false
+	}
+
+	return strings.TrimSpace(ref[:i]), strings.TrimSpace(ref[i+1 : len(ref)-1]), true
+}
+
+// resolutionError is an error resolving a variable reference.  The variable is the one whose value could not be looked up, if any.
+type resolutionError struct {
+	message  string
+	chain    []string
+	variable string
+}
+
+func (e *resolutionError) Error() string {
+	if len(e.chain) > 1 {
+		return fmt.Sprintf("%s (resolution: %s)", e.message, strings.Join(e.chain, " -> "))
+	}
+
+	return e.message
+}
+
+// blamedVariable returns the variable which caused the error, or an empty string if it was not caused by the value of a variable.
+func blamedVariable(err error) string {
+	var re *resolutionError
+
+	if errors.As(err, &re) {
+		return re.variable
+	}
+
+	return ""
+}
+
+// rootVariable returns the variable a reference starts from, e.g. "LOC" for "LOCS[LOCS_FROM_DISPLAY_NAME[LOC]]", or an empty string if
+// the reference starts from a literal key.
+func rootVariable(ref string) string {
+	for {
+		_, key, isLookup := splitLookup(ref)
+
+		if !isLookup {
+			return ref
+		}
+
+		if _, isLiteral := unquoteLiteral(key); isLiteral {
+			return ""
+		}
+
+		ref = key
+	}
+}
+
+// sanitizeResult cleans the name the same way the azurecaf provider does: the name is lowercased (if required by the type), every character
+// matching the cleanup regex is removed and the result is truncated to the maximum length of the type.
+func sanitizeResult(result string, typeInfo typeFields) (string, error) {
+	re, err := regexp.Compile(typeInfo.CleanupRegex)
+
+	if err != nil {
+		return result, err
+	}
+
+	if typeInfo.Lowercase {
+		result = strings.ToLower(result)
+	}
+
+	result = re.ReplaceAllString(result, "")
+
+	if typeInfo.MaxLength > 0 && len(result) > typeInfo.MaxLength {
+		// max_length counts bytes, cut before a multi-byte character rather than in the middle of it
+		cut := typeInfo.MaxLength
+
+		for cut > 0 && !utf8.RuneStart(result[cut]) {
+			cut--
 		}
+
+		// do not leave a dangling separator where the name was cut
+		result = strings.TrimRight(result[:cut], "-_.")
+	}
+
+	return result, nil
+}
+
+// validationFailures checks every rule of the type on its own, so a name which matches the validation regex is still
+// rejected when it breaks the length or case rules.
+func validationFailures(result string, typeInfo typeFields) (failures []string, err error) {
+	if typeInfo.MaxLength > 0 && len(result) > typeInfo.MaxLength {
+		failures = append(failures, fmt.Sprintf("resulting name is too long (%d > %d): %s", len(result), typeInfo.MaxLength, result))
+	}
+
+	if len(result) < typeInfo.MinLength {
+		failures = append(failures, fmt.Sprintf("resulting name is too short (%d < %d): %s", len(result), typeInfo.MinLength, result))
+	}
+
+	if typeInfo.Lowercase && strings.ToLower(result) != result {
+		failures = append(failures, fmt.Sprintf("resulting name must be lowercase: %s", result))
+	}
+
+	re, err := regexp.Compile(typeInfo.ValidatationRegex)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !re.MatchString(result) {
+		failures = append(failures, fmt.Sprintf("Resulting name does not match the validation regex (validation regex: %s): %q", typeInfo.ValidatationRegex, result))
 	}
 
-	return resp.Error
+	return failures, nil
 }
@@ -123,6 +123,28 @@ func TestCustomNameFunction_TooLong(t *testing.T) {
 	})
 }
 
+func TestCustomNameFunction_InvalidValidationRegex(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"namep": providerserver.NewProtocol6WithError(provider.New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf("%s %s %s", config_with_default_format_fmt, invalid_validation_regex_config, `output "test" {
+					value = provider::namep::namestring("bad_regex", local.bad_regex_config, { name = "main" })
+				}`),
+				ExpectError: regexp.MustCompile(`Invalid\s+validation\s+regex\s+"\[a-"`),
+			},
+			{
+				Config: fmt.Sprintf("%s %s %s", config_with_default_format_fmt, invalid_validation_regex_config, `output "test" {
+					value = provider::namep::validate_name("bad_regex", "main", local.bad_regex_config)
+				}`),
+				ExpectError: regexp.MustCompile(`Invalid\s+validation\s+regex\s+"\[a-"`),
+			},
+		},
+	})
+}
+
 func TestCustomNameFunction_Bad_Case(t *testing.T) {
 	resource.UnitTest(t, resource.TestCase{
 		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
@@ -139,6 +161,365 @@ func TestCustomNameFunction_Bad_Case(t *testing.T) {
 	})
 }
 
+func TestCustomNameFunction_Sanitize(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"namep": providerserver.NewProtocol6WithError(provider.New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf("%s %s", config_with_default_format_fmt, `output "test" {
+					value = provider::namep::namestring("sanitized", local.config, { name = "My_Storage.Account" })
+				}`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.StringExact("stmyappdevweumystorageac")),
+				},
+			},
+		},
+	})
+}
+
+func TestCustomNameFunction_SanitizeMultiByte(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"namep": providerserver.NewProtocol6WithError(provider.New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf("%s %s", fmt.Sprintf(default_config_fmt, `formats = {
+					azure_dashes_global = "#{SLUG}-#{NAME}"
+				}`), `locals {
+					multi_byte_config = merge(local.config, {
+					  types = {
+					    multi_byte = {
+					      name             = "multi_byte"
+					      slug             = "u"
+					      min_length       = 1
+					      max_length       = 5
+					      lowercase        = false
+					      validation_regex = "^.*$"
+					      cleanup_regex    = "-"
+					      default_selector = "azure_dashes_global"
+					    }
+					  }
+					})
+				}
+
+				output "test" {
+					value = provider::namep::namestring("multi_byte", local.multi_byte_config, { name = "aéé" })
+				}`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.StringExact("uaé")),
+				},
+			},
+		},
+	})
+}
+
+func TestCustomNameFunction_AzureCafSanitize(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"namep": providerserver.NewProtocol6WithError(provider.New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `data "namep_azure_caf_types" "example" {
+						   static = true
+						   sanitize = true
+						 }
+
+						 data "namep_configuration" "example" {
+						   types = data.namep_azure_caf_types.example.types
+						   formats = {
+						     azure = "#{SLUG}-#{APP}-#{NAME}"
+						   }
+
+						   variables = {
+						     name = "main_pool"
+						     app = "myapp"
+						   }
+						 }
+
+				output "test" {
+					value = provider::namep::namestring("aks_node_pool_linux", data.namep_configuration.example.configuration)
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.StringExact("nplmyappmain")),
+				},
+			},
+		},
+	})
+}
+
+func TestCustomNameFunction_Filters(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"namep": providerserver.NewProtocol6WithError(provider.New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf("%s %s", config_with_filters_format_fmt, `output "test" {
+					value = provider::namep::namestring("azurerm_resource_group", local.config, { name = "My_Group" })
+				}`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.StringExact("rg-mya-weu-mygroup-0uxx1")),
+				},
+			},
+		},
+	})
+}
+
+func TestCustomNameFunction_UnknownFilter(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"namep": providerserver.NewProtocol6WithError(provider.New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf("%s %s", fmt.Sprintf(default_config_fmt, `formats = {
+					azure_dashes_global = "#{SLUG}-#{NAME|bogus}"
+				}`), `output "test" {
+					value = provider::namep::namestring("azurerm_resource_group", local.config)
+				}`),
+				ExpectError: regexp.MustCompile(`unknown\s+filter\s+"bogus"`),
+			},
+		},
+	})
+}
+
+func TestCustomNameFunction_Defaults(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"namep": providerserver.NewProtocol6WithError(provider.New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf("%s %s", fmt.Sprintf(default_config_fmt, `formats = {
+					azure_dashes_global = "#{SLUG}-#{APP}-#{INSTANCE:-01}-#{NAME}#{-MISSING?}"
+				}`), `output "test" {
+					value = provider::namep::namestring("azurerm_resource_group", local.config, { name = "main" })
+				}`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.StringExact("rg-myapp-01-main")),
+				},
+			},
+		},
+	})
+}
+
+func TestCustomNameFunction_HashUsedVariables(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"namep": providerserver.NewProtocol6WithError(provider.New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf("%s %s", fmt.Sprintf(default_config_fmt, `formats = {
+					azure_dashes_global = "#{SLUG}-#{APP}-#{LOCS[LOC]}-#{HASH:6}"
+				}`), `output "name" {
+					value = provider::namep::namestring("azurerm_resource_group", local.config)
+				}
+
+				output "unused_variable" {
+					value = provider::namep::namestring("azurerm_resource_group", local.config) == provider::namep::namestring("azurerm_resource_group", local.config, { unused = "changed" })
+				}
+
+				output "used_variable" {
+					value = provider::namep::namestring("azurerm_resource_group", local.config) == provider::namep::namestring("azurerm_resource_group", local.config, { app = "other" })
+				}`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						// the unknown "testoutput" variable is not used by the format
+						plancheck.ExpectKnownOutputValue("name", knownvalue.StringRegexp(regexp.MustCompile(`^rg-myapp-weu-[0-9a-z]{6}$`))),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("unused_variable", knownvalue.Bool(true)),
+					statecheck.ExpectKnownOutputValue("used_variable", knownvalue.Bool(false)),
+				},
+			},
+		},
+	})
+}
+
+func TestCustomNameFunction_TrailingDash(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"namep": providerserver.NewProtocol6WithError(provider.New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf("%s %s", fmt.Sprintf(default_config_fmt, `formats = {
+					azure_dashes_global = "#{SLUG}-#{NAME-}#{APP}"
+				}`), `output "test" {
+					value = provider::namep::namestring("azurerm_resource_group", local.config, { name = "main" })
+				}`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.StringExact("rg-main-myapp")),
+				},
+			},
+		},
+	})
+}
+
+func TestCustomNameFunction_TrailingDashWithFilters(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"namep": providerserver.NewProtocol6WithError(provider.New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf("%s %s", fmt.Sprintf(default_config_fmt, `formats = {
+					azure_dashes_global = "#{SLUG}-#{NAME|trunc:3-}#{APP}"
+				}`), `output "test" {
+					value = provider::namep::namestring("azurerm_resource_group", local.config, { name = "mainly" })
+				}`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.StringExact("rg-mai-myapp")),
+				},
+			},
+			{
+				// the dash is an argument of the filter, not an optional dash
+				Config: fmt.Sprintf("%s %s", fmt.Sprintf(default_config_fmt, `formats = {
+					azure_dashes_global = "#{SLUG}-#{NAME|replace:_:-}"
+				}`), `output "test" {
+					value = provider::namep::namestring("azurerm_resource_group", local.config, { name = "my_group" })
+				}`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.StringExact("rg-my-group")),
+				},
+			},
+		},
+	})
+}
+
+func TestCustomNameFunction_EmptyDefault(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"namep": providerserver.NewProtocol6WithError(provider.New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf("%s %s", fmt.Sprintf(default_config_fmt, `formats = {
+					azure_dashes_global = "#{SLUG}-#{APP}#{MISSING:-}#{-OTHER:-}"
+				}`), `output "test" {
+					value = provider::namep::namestring("azurerm_resource_group", local.config)
+				}`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.StringExact("rg-myapp")),
+				},
+			},
+		},
+	})
+}
+
+func TestCustomNameFunction_Hash(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"namep": providerserver.NewProtocol6WithError(provider.New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf("%s %s", fmt.Sprintf(default_config_fmt, `formats = {
+					azure_dashes_global = "#{SLUG}-#{APP}-#{HASH(APP,ENV):4}-#{HASH(APP):8:hex}"
+				}`), `output "test" {
+					value = provider::namep::namestring("azurerm_resource_group", local.config)
+				}`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.StringExact("rg-myapp-jamy-9cc2d700")),
+				},
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownOutputValue("test", knownvalue.StringExact("rg-myapp-jamy-9cc2d700")),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestCustomNameFunction_Shrink(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"namep": providerserver.NewProtocol6WithError(provider.New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf("%s %s", fmt.Sprintf(default_config_fmt, `formats = {
+					azure_dashes_global = "#{SLUG}-#{APP|shrink:1:3:2}-#{ENV}-#{NAME|shrink:2}-#{HASH(APP,ENV):4}"
+				}`), `output "test_short" {
+					value = provider::namep::namestring("short", local.config, { app = "averyverylongapplication", name = "somename" })
+				}
+				output "test_long" {
+					value = provider::namep::namestring("azurerm_resource_group", local.config, { app = "averyverylongapplication", name = "somename" })
+				}`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_short", knownvalue.StringExact("sh-aoo-dev-somename-s115")),
+					statecheck.ExpectKnownOutputValue("test_long", knownvalue.StringExact("rg-averyverylongapplication-dev-somename-s115")),
+				},
+			},
+		},
+	})
+}
+
+func TestCustomNameFunction_Conditionals(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"namep": providerserver.NewProtocol6WithError(provider.New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf("%s %s", fmt.Sprintf(default_config_fmt, `formats = {
+					azure_dashes_global = "#{SLUG}-#{APP}#{if ENV != \"prod\"}-#{ENV}#{end}#{?INSTANCE}-#{INSTANCE}#{/}"
+				}`), `output "test_dev" {
+					value = provider::namep::namestring("azurerm_resource_group", local.config)
+				}
+				output "test_prod" {
+					value = provider::namep::namestring("azurerm_resource_group", local.config, { env = "prod", instance = "02" })
+				}`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_dev", knownvalue.StringExact("rg-myapp-dev")),
+					statecheck.ExpectKnownOutputValue("test_prod", knownvalue.StringExact("rg-myapp-02")),
+				},
+			},
+		},
+	})
+}
+
+func TestCustomNameFunction_AllErrors(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"namep": providerserver.NewProtocol6WithError(provider.New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(default_config_fmt, `formats = {
+					azure_dashes_global = "#{SLUG}-#{MISSING}-#{NOMAP[LOC]}"
+				}`) + `output "test" {
+					value = provider::namep::namestring("azurerm_resource_group", local.config)
+				}`,
+				ExpectError: regexp.MustCompile(`(?s)Invalid\s+value\s+for\s+"configurations"\s+parameter.*"MISSING".*"NOMAP"`),
+			},
+		},
+	})
+}
+
+func TestCustomNameFunction_OverrideError(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"namep": providerserver.NewProtocol6WithError(provider.New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf("%s %s", config_with_default_format_fmt, `output "test" {
+					value = provider::namep::namestring("azurerm_resource_group", local.config, { name = "main" }, { loc = "mars" })
+				}`),
+				ExpectError: regexp.MustCompile(`Invalid\s+value\s+for\s+"overrides"\s+parameter`),
+			},
+		},
+	})
+}
+
 func TestCustomNameFunction_AzureCaf(t *testing.T) {
 	resource.UnitTest(t, resource.TestCase{
 		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
@@ -193,6 +574,109 @@ func TestCustomNameFunction_Config(t *testing.T) {
 	})
 }
 
+func TestCustomNameFunction_NestedLookup(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"namep": providerserver.NewProtocol6WithError(provider.New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `data "namep_azure_locations" "example" {
+						   static = true
+						 }
+
+						 data "namep_configuration" "example" {
+						   variable_maps = data.namep_azure_locations.example.location_maps
+						   formats = {
+						     custom = "#{APP}-#{LOCS[LOCS_FROM_DISPLAY_NAME[LOC]]}"
+						   }
+
+						   variables = {
+						     app = "myapp"
+						     loc = "West Europe"
+						   }
+						 }
+
+				output "test" {
+					value = provider::namep::namestring("custom_type", data.namep_configuration.example.configuration)
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.StringExact("myapp-weu")),
+				},
+			},
+		},
+	})
+}
+
+func TestCustomNameFunction_NestedLookupError(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"namep": providerserver.NewProtocol6WithError(provider.New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `data "namep_configuration" "example" {
+						   variable_maps = {
+						     locs = {
+						       westeurope = "weu"
+						     }
+						     locs_from_display_name = {
+						       "west europe" = "westeu"
+						     }
+						   }
+						   formats = {
+						     custom = "#{LOCS[LOCS_FROM_DISPLAY_NAME[LOC]]}"
+						   }
+
+						   variables = {
+						     loc = "West Europe"
+						   }
+						 }
+
+				output "test" {
+					value = provider::namep::namestring("custom_type", data.namep_configuration.example.configuration)
+				}`,
+				ExpectError: regexp.MustCompile(`LOCS_FROM_DISPLAY_NAME\["West\s+Europe"\]\s+=\s+"westeu"`),
+			},
+		},
+	})
+}
+
+func TestCustomNameFunction_LiteralLookup(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"namep": providerserver.NewProtocol6WithError(provider.New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `data "namep_configuration" "example" {
+						   variable_maps = {
+						     locs = {
+						       westeurope = "weu"
+						       "*" = "xx"
+						     }
+						   }
+						   formats = {
+						     custom = "#{APP}-#{LOCS[\"westeurope\"]}-#{LOCS[LOC]}"
+						   }
+
+						   variables = {
+						     app = "myapp"
+						     loc = "newregion"
+						   }
+						 }
+
+				output "test" {
+					value = provider::namep::namestring("custom_type", data.namep_configuration.example.configuration)
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.StringExact("myapp-weu-xx")),
+				},
+			},
+		},
+	})
+}
+
 func TestCustomNameFunction_DelayConfig(t *testing.T) {
 	resource.UnitTest(t, resource.TestCase{
 		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
@@ -366,6 +850,7 @@ locals {
 		  max_length = 90
 		  lowercase = true
 		  validation_regex = "^[a-z0-9-]*$"
+		  cleanup_regex = ""
 		  default_selector = "azure_dashes_global"
 		}
 		too_short = {
@@ -375,6 +860,7 @@ locals {
 		  max_length = 200
 		  lowercase = true
 		  validation_regex = "^[a-z0-9-]{100-200}$"
+		  cleanup_regex = ""
 		  default_selector = "azure_dashes_global"
 		}
 		too_long = {
@@ -384,6 +870,27 @@ locals {
 		  max_length = 2
 		  lowercase = true
 		  validation_regex = "^[a-z0-9-]{1-2}$"
+		  cleanup_regex = ""
+		  default_selector = "azure_dashes_global"
+		}
+		short = {
+		  name = "short"
+		  slug = "sh"
+		  min_length = 1
+		  max_length = 24
+		  lowercase = true
+		  validation_regex = "^[a-z0-9-]{1,24}$"
+		  cleanup_regex = ""
+		  default_selector = "azure_dashes_global"
+		}
+		sanitized = {
+		  name = "sanitized"
+		  slug = "st"
+		  min_length = 3
+		  max_length = 24
+		  lowercase = true
+		  validation_regex = "^[a-z0-9]{3,24}$"
+		  cleanup_regex = "[^0-9a-z]"
 		  default_selector = "azure_dashes_global"
 		}
 	  }
@@ -395,6 +902,25 @@ var config_with_rg_format_fmt = fmt.Sprintf(default_config_fmt, `formats = {
 	    azurerm_resource_group = "#{APP}-#{env}-#{LOCS[LOC]}-#{NAME}#{-SALT}"
 }`)
 
+const invalid_validation_regex_config = `
+locals {
+	bad_regex_config = merge(local.config, {
+	  types = {
+	    bad_regex = {
+	      name             = "bad_regex"
+	      slug             = "br"
+	      min_length       = 1
+	      max_length       = 10
+	      lowercase        = true
+	      validation_regex = "[a-"
+	      cleanup_regex    = ""
+	      default_selector = "azure_dashes_global"
+	    }
+	  }
+	})
+}
+`
+
 var config_with_default_format_fmt = fmt.Sprintf(default_config_fmt, `formats = {
 	azure_dashes_global = "#{SLUG}-#{APP}-#{env}-#{LOCS[LOC]}-#{NAME}#{-SALT}"
 }`)
@@ -403,6 +929,10 @@ var config_with_default_delayed_format_fmt = fmt.Sprintf(default_config_fmt, `fo
 	azure_dashes_global = "#{SLUG}-#{APP}-#{env}-#{LOCS[LOC]}-#{testoutput}#{-SALT}"
 }`)
 
+var config_with_filters_format_fmt = fmt.Sprintf(default_config_fmt, `formats = {
+	azure_dashes_global = "#{SLUG}-#{APP|trunc:3}-#{LOCS[LOC]}-#{NAME|alnum|lower}#{-SALT|pad:5}"
+}`)
+
 const config_with_azure_caf_types_fmt = `
 data "namep_azure_caf_types" "example" {}
 
@@ -440,6 +970,7 @@ data "namep_configuration" "example" {
 			max_length = 90
 			lowercase = true
 			validation_regex = "^.*$"
+			cleanup_regex = ""
 			default_selector = "generic_first_second"
 		}
 	}
@@ -0,0 +1,133 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the desired interfaces.
+var _ function.Function = &NameStringsFunction{}
+
+// resourceTypeKey is the key of a request which holds the resource type, all other keys are variable overrides.
+const resourceTypeKey = "resource_type"
+
+func NewNameStringsFunction() function.Function {
+	return &NameStringsFunction{}
+}
+
+type NameStringsFunction struct{}
+
+func (f *NameStringsFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "namestrings"
+}
+
+func (f *NameStringsFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Generate several name strings from the same configuration",
+		Description: `This function creates the names for many resources at once.  It produces the same names as the ` + "`namestring`" + ` function,
+					  but the configuration is only processed once for all of the names.  Generating the same name for more than one request is an error.`,
+
+		Parameters: []function.Parameter{
+			function.MapParameter{
+				Name: "requests",
+				Description: "Map of the names to create.  Each request is a map with the resource type in the `" + resourceTypeKey + "` entry, " +
+					"all other entries override the `variables` map which was passed in the configuration parameter.",
+				ElementType: types.MapType{ElemType: types.StringType},
+			},
+			configurationParameter("configuration"),
+		},
+
+		Return: function.MapReturn{ElementType: types.StringType},
+	}
+}
+
+func (f *NameStringsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var requestsArg map[string]map[string]string
+	var configurationObj types.Object
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &requestsArg, &configurationObj))
+
+	if resp.Error != nil {
+		return
+	}
+
+	config, known, funcErr := newNameConfiguration(ctx, configurationObj, argumentPositions{resourceType: 0, configuration: 1, overrides: 0})
+	resp.Error = function.ConcatFuncErrors(resp.Error, funcErr)
+
+	if resp.Error != nil || !known {
+		return
+	}
+
+	keys := make([]string, 0, len(requestsArg))
+	for k := range requestsArg {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	names := make(map[string]attr.Value, len(requestsArg))
+	generatedBy := make(map[string]string, len(requestsArg))
+
+	for _, k := range keys {
+		request := requestsArg[k]
+		resourceType, exists := request[resourceTypeKey]
+
+		if !exists {
+			resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(0, fmt.Sprintf("Request %q: no %q entry", k, resourceTypeKey)))
+			continue
+		}
+
+		overrides := make(map[string]string, len(request)-1)
+		for ok, ov := range request {
+			if ok != resourceTypeKey {
+				overrides[ok] = ov
+			}
+		}
+
+		name, funcErr := config.generateName(ctx, resourceType, []map[string]string{overrides}, nil)
+
+		if funcErr != nil {
+			// the text joins every error of the request with newlines, each of them needs the request
+			lines := strings.Split(funcErr.Text, "\n")
+
+			for i, line := range lines {
+				lines[i] = fmt.Sprintf("Request %q: %s", k, line)
+			}
+
+			resp.Error = function.ConcatFuncErrors(resp.Error, &function.FuncError{
+				Text:             strings.Join(lines, "\n"),
+				FunctionArgument: funcErr.FunctionArgument,
+			})
+			continue
+		}
+
+		names[k] = name
+
+		if name.IsUnknown() {
+			continue
+		}
+
+		if other, duplicate := generatedBy[name.ValueString()]; duplicate {
+			resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(0, fmt.Sprintf("Duplicate name %q generated for requests %q and %q", name.ValueString(), other, k)))
+			continue
+		}
+
+		generatedBy[name.ValueString()] = k
+	}
+
+	if resp.Error != nil {
+		return
+	}
+
+	result, diags := types.MapValue(types.StringType, names)
+	resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, diags))
+
+	if resp.Error == nil {
+		resp.Error = resp.Result.Set(ctx, result)
+	}
+}
@@ -0,0 +1,93 @@
+package functions_test
+
+import (
+	"fmt"
+	"regexp"
+	"terraform-provider-namep/internal/provider"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+)
+
+func TestNameStringsFunction_Batch(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"namep": providerserver.NewProtocol6WithError(provider.New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf("%s %s", config_with_default_format_fmt, `output "test" {
+					value = provider::namep::namestrings({
+						main  = { resource_type = "azurerm_resource_group" }
+						other = { resource_type = "azurerm_resource_group", name = "other" }
+					}, local.config)
+				}`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.MapExact(map[string]knownvalue.Check{
+						"main":  knownvalue.StringExact("rg-myapp-dev-weu-main-uxx1"),
+						"other": knownvalue.StringExact("rg-myapp-dev-weu-other-uxx1"),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func TestNameStringsFunction_Duplicate(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"namep": providerserver.NewProtocol6WithError(provider.New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf("%s %s", config_with_default_format_fmt, `output "test" {
+					value = provider::namep::namestrings({
+						first  = { resource_type = "azurerm_resource_group" }
+						second = { resource_type = "azurerm_resource_group", name = "main" }
+					}, local.config)
+				}`),
+				ExpectError: regexp.MustCompile(`Duplicate\s+name\s+"rg-myapp-dev-weu-main-uxx1"`),
+			},
+		},
+	})
+}
+
+func TestNameStringsFunction_MissingResourceType(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"namep": providerserver.NewProtocol6WithError(provider.New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf("%s %s", config_with_default_format_fmt, `output "test" {
+					value = provider::namep::namestrings({
+						main = { name = "main" }
+					}, local.config)
+				}`),
+				ExpectError: regexp.MustCompile(`no\s+"resource_type"\s+entry`),
+			},
+		},
+	})
+}
+
+func TestNameStringsFunction_MultipleErrors(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"namep": providerserver.NewProtocol6WithError(provider.New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf("%s %s", config_with_default_format_fmt, `output "test" {
+					value = provider::namep::namestrings({
+						main = { resource_type = "too_long", name = "main" }
+					}, local.config)
+				}`),
+				ExpectError: regexp.MustCompile(`Request\s+"main":\s+resulting\s+name\s+is\s+too\s+long[\s\S]*Request\s+"main":\s+Resulting\s+name\s+does\s+not\s+match`),
+			},
+		},
+	})
+}
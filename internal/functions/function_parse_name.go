@@ -0,0 +1,104 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the desired interfaces.
+var _ function.Function = &ParseNameFunction{}
+
+func NewParseNameFunction() function.Function {
+	return &ParseNameFunction{}
+}
+
+type ParseNameFunction struct{}
+
+func (f *ParseNameFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_name"
+}
+
+func (f *ParseNameFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Recover the variables used to create a name",
+		Description: `This function is the reverse of ` + "`namestring`" + `.  The format for the resource type is selected the same way and the values of the variables
+					  used by the format are recovered from the name.  Variable map lookups are inverted, so a location short name is turned back into the location.
+					  SLUG, hashes and lookups with a literal key (e.g. ` + "`#{LOCS[\"westeurope\"]}`" + `) do not reference a variable and set none.  A lookup whose value in the
+					  name only matches the default entry (` + "`*`" + `) of the variable map cannot be recovered and is an error.`,
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "resource_type",
+				Description: "Type of resource the name is for (required for selecting format)",
+			},
+			function.StringParameter{
+				Name:        "name",
+				Description: "The name to parse",
+			},
+			configurationParameter("configuration"),
+		},
+
+		Return: function.MapReturn{ElementType: types.StringType},
+	}
+}
+
+func (f *ParseNameFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var resourceType string
+	var name string
+	var configurationObj types.Object
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &resourceType, &name, &configurationObj))
+
+	if resp.Error != nil {
+		return
+	}
+
+	config, known, funcErr := newNameConfiguration(ctx, configurationObj, argumentPositions{resourceType: 0, configuration: 2})
+	resp.Error = function.ConcatFuncErrors(resp.Error, funcErr)
+
+	if resp.Error != nil || !known {
+		return
+	}
+
+	typeInfo, known, funcErr := config.typeInfo(ctx, resourceType)
+	resp.Error = function.ConcatFuncErrors(resp.Error, funcErr)
+
+	if resp.Error != nil || !known {
+		return
+	}
+
+	formatKey, format, toSearch, exists := config.findFormat(ctx, resourceType, typeInfo)
+
+	if !exists {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("No format found for resource type %q, tried %v", resourceType, toSearch))
+		return
+	}
+
+	if format.IsUnknown() {
+		return
+	}
+
+	// conditional segments are decided by the variables of the configuration
+	evaluated, isUnknown, err := evaluateConditionals(format.ValueString(), config.variables, config.variableMaps)
+
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(2, fmt.Sprintf("Invalid format %q: %v", formatKey, err))
+		return
+	}
+
+	if isUnknown {
+		return
+	}
+
+	values, err := parseName(name, evaluated, typeInfo, config.variableMaps, config.variableMapKeys)
+
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("Cannot parse name for resource type %q with format %q: %v", resourceType, formatKey, err))
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, values)
+}
@@ -0,0 +1,124 @@
+package functions_test
+
+import (
+	"fmt"
+	"regexp"
+	"terraform-provider-namep/internal/provider"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+)
+
+func TestParseNameFunction_ResourceGroup(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"namep": providerserver.NewProtocol6WithError(provider.New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf("%s %s", config_with_default_format_fmt, `output "test" {
+					value = provider::namep::parse_name("azurerm_resource_group", "rg-myapp-dev-weu-main", local.config)
+				}`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.MapExact(map[string]knownvalue.Check{
+						"APP":  knownvalue.StringExact("myapp"),
+						"ENV":  knownvalue.StringExact("dev"),
+						"LOC":  knownvalue.StringExact("westeurope"),
+						"NAME": knownvalue.StringExact("main"),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func TestParseNameFunction_RoundTrip(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"namep": providerserver.NewProtocol6WithError(provider.New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf("%s %s", config_with_default_format_fmt, `output "test" {
+					value = provider::namep::parse_name("azurerm_resource_group", provider::namep::namestring("azurerm_resource_group", local.config, { name = "mygroup" }), local.config)
+				}`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.MapExact(map[string]knownvalue.Check{
+						"APP":  knownvalue.StringExact("myapp"),
+						"ENV":  knownvalue.StringExact("dev"),
+						"LOC":  knownvalue.StringExact("westeurope"),
+						"NAME": knownvalue.StringExact("mygroup"),
+						"SALT": knownvalue.StringExact("uxx1"),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func TestParseNameFunction_NoMatch(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"namep": providerserver.NewProtocol6WithError(provider.New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf("%s %s", config_with_default_format_fmt, `output "test" {
+					value = provider::namep::parse_name("azurerm_resource_group", "rg-myapp-dev-neu-main", local.config)
+				}`),
+				ExpectError: regexp.MustCompile(`does\s+not\s+match\s+the\s+format`),
+			},
+		},
+	})
+}
+
+func TestParseNameFunction_LiteralKey(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"namep": providerserver.NewProtocol6WithError(provider.New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf("%s %s", fmt.Sprintf(default_config_fmt, `formats = {
+					azure_dashes_global = "#{SLUG}-#{LOCS[\"westeurope\"]}-#{NAME}"
+				}`), `output "test" {
+					value = provider::namep::parse_name("azurerm_resource_group", "rg-weu-main", local.config)
+				}`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.MapExact(map[string]knownvalue.Check{
+						"NAME": knownvalue.StringExact("main"),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func TestParseNameFunction_DefaultEntry(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"namep": providerserver.NewProtocol6WithError(provider.New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf("%s %s", fmt.Sprintf(default_config_fmt, `formats = {
+					azure_dashes_global = "#{SLUG}-#{LOCS[LOC]}-#{NAME}"
+				}`), `output "test" {
+					value = provider::namep::parse_name("azurerm_resource_group", "rg-xx-main", merge(local.config, {
+					  variable_maps = {
+					    locs = {
+					      westeurope = "weu"
+					      "*"        = "xx"
+					    }
+					  }
+					}))
+				}`),
+				ExpectError: regexp.MustCompile(`Cannot\s+recover\s+"LOC"\s+from\s+"xx",\s+it\s+only\s+matches\s+the\s+default\s+entry`),
+			},
+		},
+	})
+}
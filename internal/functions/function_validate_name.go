@@ -0,0 +1,111 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the desired interfaces.
+var _ function.Function = &ValidateNameFunction{}
+
+var validationAttributeTypes = map[string]attr.Type{
+	"valid":      types.BoolType,
+	"errors":     types.ListType{ElemType: types.StringType},
+	"length":     types.Int64Type,
+	"max_length": types.Int64Type,
+}
+
+func NewValidateNameFunction() function.Function {
+	return &ValidateNameFunction{}
+}
+
+type ValidateNameFunction struct{}
+
+func (f *ValidateNameFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "validate_name"
+}
+
+func (f *ValidateNameFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Validate a name against the rules of a resource type",
+		Description: `This function checks a name which was not generated by ` + "`namestring`" + ` (e.g. the name of an imported resource) against the rules of the resource type
+					  in the configuration.  A resource type which is not in the types of the configuration makes the name invalid.  Instead of failing, it returns an object describing the result so it can be used in ` + "`precondition`" + ` and ` + "`check`" + ` blocks.`,
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "resource_type",
+				Description: "Type of resource the name is for (used to select the validation rules)",
+			},
+			function.StringParameter{
+				Name:        "name",
+				Description: "The name to validate",
+			},
+			configurationParameter("configuration"),
+		},
+
+		Return: function.ObjectReturn{AttributeTypes: validationAttributeTypes},
+	}
+}
+
+func (f *ValidateNameFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var resourceType string
+	var name string
+	var configurationObj types.Object
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &resourceType, &name, &configurationObj))
+
+	if resp.Error != nil {
+		return
+	}
+
+	config, known, funcErr := newNameConfiguration(ctx, configurationObj, argumentPositions{resourceType: 0, configuration: 2})
+	resp.Error = function.ConcatFuncErrors(resp.Error, funcErr)
+
+	if resp.Error != nil || !known {
+		return
+	}
+
+	typeInfo, known, funcErr := config.typeInfo(ctx, resourceType)
+	resp.Error = function.ConcatFuncErrors(resp.Error, funcErr)
+
+	if resp.Error != nil || !known {
+		return
+	}
+
+	var errors []attr.Value
+
+	// a type which is not in the configuration has no rules, so every name would be valid
+	if _, exists := config.types[resourceType]; !exists {
+		errors = append(errors, types.StringValue(fmt.Sprintf("unknown resource type %q, it is not in the types of the configuration", resourceType)))
+	}
+
+	failures, err := validationFailures(name, typeInfo)
+
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(2, fmt.Sprintf("Invalid validation regex %q for resource type %q: %v", typeInfo.ValidatationRegex, resourceType, err))
+		return
+	}
+
+	for _, failure := range failures {
+		errors = append(errors, types.StringValue(failure))
+	}
+
+	errorList, diags := types.ListValue(types.StringType, errors)
+	resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, diags))
+
+	result, diags := types.ObjectValue(validationAttributeTypes, map[string]attr.Value{
+		"valid":      types.BoolValue(len(errors) == 0),
+		"errors":     errorList,
+		"length":     types.Int64Value(int64(len(name))),
+		"max_length": types.Int64Value(int64(typeInfo.MaxLength)),
+	})
+	resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, diags))
+
+	if resp.Error == nil {
+		resp.Error = resp.Result.Set(ctx, result)
+	}
+}
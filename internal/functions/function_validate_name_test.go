@@ -0,0 +1,87 @@
+package functions_test
+
+import (
+	"fmt"
+	"terraform-provider-namep/internal/provider"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+)
+
+func TestValidateNameFunction_Valid(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"namep": providerserver.NewProtocol6WithError(provider.New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf("%s %s", config_with_default_format_fmt, `output "test" {
+					value = provider::namep::validate_name("sanitized", "stmyappdev", local.config)
+				}`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"valid":      knownvalue.Bool(true),
+						"errors":     knownvalue.ListSizeExact(0),
+						"length":     knownvalue.Int64Exact(10),
+						"max_length": knownvalue.Int64Exact(24),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func TestValidateNameFunction_Invalid(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"namep": providerserver.NewProtocol6WithError(provider.New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf("%s %s", config_with_default_format_fmt, `output "test" {
+					value = provider::namep::validate_name("sanitized", "St-MyApp-Dev", local.config)
+				}`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"valid": knownvalue.Bool(false),
+						"errors": knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.StringExact("resulting name must be lowercase: St-MyApp-Dev"),
+							knownvalue.StringExact(`Resulting name does not match the validation regex (validation regex: ^[a-z0-9]{3,24}$): "St-MyApp-Dev"`),
+						}),
+						"length":     knownvalue.Int64Exact(12),
+						"max_length": knownvalue.Int64Exact(24),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func TestValidateNameFunction_UnknownResourceType(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"namep": providerserver.NewProtocol6WithError(provider.New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf("%s %s", config_with_default_format_fmt, `output "test" {
+					value = provider::namep::validate_name("santized", "stmyappdev", local.config)
+				}`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"valid": knownvalue.Bool(false),
+						"errors": knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.StringExact(`unknown resource type "santized", it is not in the types of the configuration`),
+						}),
+						"length":     knownvalue.Int64Exact(10),
+						"max_length": knownvalue.Int64Exact(0),
+					})),
+				},
+			},
+		},
+	})
+}
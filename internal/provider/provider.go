@@ -2,15 +2,23 @@ package provider
 
 import (
 	"context"
+	"maps"
 	namep "terraform-provider-namep/internal/datasource"
 	namepf "terraform-provider-namep/internal/functions"
+	namepr "terraform-provider-namep/internal/resource"
 	"terraform-provider-namep/internal/shared"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -31,7 +39,12 @@ type namepProvider struct {
 }
 
 type namepProviderModel struct {
-	Static types.Bool `tfsdk:"static"`
+	Static       types.Bool   `tfsdk:"static"`
+	Formats      types.Map    `tfsdk:"formats"`
+	Variables    types.Map    `tfsdk:"variables"`
+	VariableMaps types.Map    `tfsdk:"variable_maps"`
+	TypesSources types.List   `tfsdk:"types_sources"`
+	TypesFile    types.String `tfsdk:"types_file"`
 }
 
 func (p *namepProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -46,6 +59,37 @@ func (p *namepProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp
 				Description: "Static flag to determine if all applicable data sources should use static setting, defaults to false.",
 				Optional:    true,
 			},
+			"formats": schema.MapAttribute{
+				Description: "Map of formats of the default configuration.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"variables": schema.MapAttribute{
+				Description: "Map of variables of the default configuration.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"variable_maps": schema.MapAttribute{
+				Description: "Map of maps of variables of the default configuration.",
+				Optional:    true,
+				ElementType: types.MapType{
+					ElemType: types.StringType,
+				},
+			},
+			"types_sources": schema.ListAttribute{
+				Description: "The types built into the provider to use as the types of the default configuration, any of `azure_caf`, `aws`, `gcp` and `kubernetes`.  These are the types " +
+					"the types data sources return with `static` set to true.  Types of later sources replace types of the same name of earlier sources.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.OneOf(namep.TypesSources...)),
+				},
+			},
+			"types_file": schema.StringAttribute{
+				Description: "Path of a YAML or JSON file with types of the default configuration, in the format of the [namep_types_file](data-sources/types_file.md) data source.  " +
+					"The types of the file replace types of the same name of `types_sources`.",
+				Optional: true,
+			},
 		},
 	}
 }
@@ -61,25 +105,106 @@ func (p *namepProvider) Configure(ctx context.Context, req provider.ConfigureReq
 	var npConfig shared.NamepConfig
 
 	npConfig.Static = config.Static.ValueBool()
+	npConfig.Configuration = defaultConfiguration(ctx, config, &resp.Diagnostics)
 
 	resp.DataSourceData = npConfig
 	resp.ResourceData = npConfig
 }
 
+// defaultConfiguration creates the default configuration from the provider configuration.  It is null if none of its attributes are configured and
+// unknown if any of them are unknown.
+func defaultConfiguration(ctx context.Context, config namepProviderModel, diags *diag.Diagnostics) types.Object {
+	attrTypes := namepf.ConfigurationAttributeTypes()
+	values := []attr.Value{config.Formats, config.Variables, config.VariableMaps, config.TypesSources, config.TypesFile}
+	configured := false
+
+	for _, v := range values {
+		// an unknown element (e.g. a variable which depends on a resource) makes the whole configuration unknown
+		if tv, err := v.ToTerraformValue(ctx); err != nil || !tv.IsFullyKnown() {
+			return types.ObjectUnknown(attrTypes)
+		}
+
+		configured = configured || !v.IsNull()
+	}
+
+	if !configured {
+		return types.ObjectNull(attrTypes)
+	}
+
+	var sources []string
+	diags.Append(config.TypesSources.ElementsAs(ctx, &sources, false)...)
+
+	typeInfoMap := make(map[string]shared.TypeFields)
+
+	for _, source := range sources {
+		maps.Copy(typeInfoMap, namep.StaticTypes(source))
+	}
+
+	if !config.TypesFile.IsNull() {
+		fileTypes, errs := namep.LoadTypesFile(config.TypesFile.ValueString())
+
+		for _, err := range errs {
+			diags.AddAttributeError(path.Root("types_file"), "Invalid types file", err)
+		}
+
+		maps.Copy(typeInfoMap, fileTypes)
+	}
+
+	typesValue, d := types.MapValueFrom(ctx, attrTypes["types"].(types.MapType).ElemType, typeInfoMap)
+	diags.Append(d...)
+
+	if diags.HasError() {
+		return types.ObjectNull(attrTypes)
+	}
+
+	c, d := types.ObjectValue(attrTypes, map[string]attr.Value{
+		"formats":       emptyIfNull(config.Formats),
+		"variables":     emptyIfNull(config.Variables),
+		"variable_maps": emptyIfNull(config.VariableMaps),
+		"types":         typesValue,
+	})
+	diags.Append(d...)
+
+	return c
+}
+
+func emptyIfNull(m types.Map) types.Map {
+	if m.IsNull() {
+		return types.MapValueMust(m.ElementType(context.Background()), map[string]attr.Value{})
+	}
+
+	return m
+}
+
 func (p *namepProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		namep.NewAzureCafTypes,
 		namep.NewConfiguration,
 		namep.NewAzureLocations,
+		namep.NewNamePreview,
+		namep.NewAwsTypes,
+		namep.NewGcpTypes,
+		namep.NewKubernetesTypes,
+		namep.NewTypesFile,
+		namep.NewConventionFile,
+		namep.NewAzureNameAvailability,
 	}
 }
 
 func (p *namepProvider) Resources(_ context.Context) []func() resource.Resource {
-	return []func() resource.Resource{}
+	return []func() resource.Resource{
+		namepr.NewName,
+		namepr.NewReservation,
+		namepr.NewSequence,
+	}
 }
 
 func (p *namepProvider) Functions(_ context.Context) []func() function.Function {
 	return []func() function.Function{
 		namepf.NewNameStringFunction,
+		namepf.NewNameStringsFunction,
+		namepf.NewValidateNameFunction,
+		namepf.NewParseNameFunction,
+		namepf.NewExplainNameFunction,
 	}
 }
@@ -0,0 +1,126 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// lockTimeout is how long to wait for the lock of a file registry.
+var lockTimeout = 30 * time.Second
+
+// fileBackend stores the registry in a local JSON file.  Writes are serialized with a lock file next to it, so it can be shared by the runs
+// on one machine or on a shared file system.
+type fileBackend struct {
+	path string
+}
+
+func NewFileBackend(path string) Backend {
+	return &fileBackend{path: path}
+}
+
+func (b *fileBackend) String() string {
+	return fmt.Sprintf("file %q", b.path)
+}
+
+func (b *fileBackend) Load(ctx context.Context) ([]byte, string, error) {
+	data, err := os.ReadFile(b.path)
+
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, "", nil
+	}
+
+	if err != nil {
+		return nil, "", fmt.Errorf("cannot read registry %s: %v", b, err)
+	}
+
+	return data, fileVersion(data), nil
+}
+
+func (b *fileBackend) Store(ctx context.Context, data []byte, version string) error {
+	unlock, err := b.lock(ctx)
+
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	_, currentVersion, err := b.Load(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	if currentVersion != version {
+		return ErrConflict
+	}
+
+	// write a temporary file and rename it, so readers never see a partially written registry
+	tmp, err := os.CreateTemp(filepath.Dir(b.path), filepath.Base(b.path)+".*.tmp")
+
+	if err != nil {
+		return fmt.Errorf("cannot write registry %s: %v", b, err)
+	}
+
+	_, err = tmp.Write(data)
+
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Rename(tmp.Name(), b.path)
+	}
+
+	if err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("cannot write registry %s: %v", b, err)
+	}
+
+	return nil
+}
+
+// lock creates the lock file, waiting for it to be removed if it already exists.
+func (b *fileBackend) lock(ctx context.Context) (unlock func(), err error) {
+	lockPath := b.path + ".lock"
+	deadline := time.Now().Add(lockTimeout)
+
+	for {
+		f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+
+		if err == nil {
+			f.Close()
+			return func() { os.Remove(lockPath) }, nil
+		}
+
+		if !errors.Is(err, fs.ErrExist) {
+			return nil, fmt.Errorf("cannot lock registry %s: %v", b, err)
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("cannot lock registry %s: %q still exists after %v (remove it if no other run is using the registry)", b, lockPath, lockTimeout)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+func fileVersion(data []byte) string {
+	if data == nil {
+		return ""
+	}
+
+	sum := sha256.Sum256(bytes.TrimSpace(data))
+	return hex.EncodeToString(sum[:])
+}
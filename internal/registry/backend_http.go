@@ -0,0 +1,92 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// httpTimeout is how long a request to an HTTP registry may take.
+var httpTimeout = 30 * time.Second
+
+// httpBackend stores the registry document at a URL.  The document is read with GET and written with PUT, using the ETag of the GET
+// response in an If-Match header (or If-None-Match: * if there is no document yet), so the server must answer 412 Precondition Failed if
+// the document was changed in the meantime.
+type httpBackend struct {
+	url    string
+	client *http.Client
+}
+
+func NewHTTPBackend(url string) Backend {
+	return &httpBackend{url: url, client: &http.Client{Timeout: httpTimeout}}
+}
+
+func (b *httpBackend) String() string {
+	return fmt.Sprintf("URL %q", b.url)
+}
+
+func (b *httpBackend) Load(ctx context.Context) ([]byte, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, b.url, nil)
+
+	if err != nil {
+		return nil, "", fmt.Errorf("cannot read registry %s: %v", b, err)
+	}
+
+	resp, err := b.client.Do(req)
+
+	if err != nil {
+		return nil, "", fmt.Errorf("cannot read registry %s: %v", b, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, "", nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("cannot read registry %s: unexpected http GET status: %s", b, resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+
+	if err != nil {
+		return nil, "", fmt.Errorf("cannot read registry %s: %v", b, err)
+	}
+
+	return data, resp.Header.Get("ETag"), nil
+}
+
+func (b *httpBackend) Store(ctx context.Context, data []byte, version string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, b.url, bytes.NewReader(data))
+
+	if err != nil {
+		return fmt.Errorf("cannot write registry %s: %v", b, err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	if version == "" {
+		req.Header.Set("If-None-Match", "*")
+	} else {
+		req.Header.Set("If-Match", version)
+	}
+
+	resp, err := b.client.Do(req)
+
+	if err != nil {
+		return fmt.Errorf("cannot write registry %s: %v", b, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated, http.StatusNoContent:
+		return nil
+	case http.StatusPreconditionFailed, http.StatusConflict:
+		return ErrConflict
+	default:
+		return fmt.Errorf("cannot write registry %s: unexpected http PUT status: %s", b, resp.Status)
+	}
+}
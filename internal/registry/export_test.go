@@ -0,0 +1,25 @@
+package registry
+
+import (
+	"testing"
+	"time"
+)
+
+// UpdateAttempts is the number of times an update is tried before it fails with ErrConflict.
+const UpdateAttempts = updateAttempts
+
+// SetLockTimeout changes how long to wait for the lock of a file registry until the end of the test.
+func SetLockTimeout(t *testing.T, timeout time.Duration) {
+	lockTimeoutOrig := lockTimeout
+	lockTimeout = timeout
+
+	t.Cleanup(func() { lockTimeout = lockTimeoutOrig })
+}
+
+// SetHTTPTimeout changes how long a request to an HTTP registry may take for the backends created until the end of the test.
+func SetHTTPTimeout(t *testing.T, timeout time.Duration) {
+	httpTimeoutOrig := httpTimeout
+	httpTimeout = timeout
+
+	t.Cleanup(func() { httpTimeout = httpTimeoutOrig })
+}
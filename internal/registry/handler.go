@@ -0,0 +1,64 @@
+package registry
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// NewHandler returns an HTTP handler serving the document of the backend with the protocol expected by the HTTP backend, e.g. to share a
+// file registry with other machines.
+func NewHandler(backend Backend) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			data, version, err := backend.Load(r.Context())
+
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+
+			if data == nil {
+				http.NotFound(w, r)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("ETag", `"`+version+`"`)
+			w.Write(data)
+		case http.MethodPut:
+			version := strings.Trim(r.Header.Get("If-Match"), `"`)
+
+			if version == "" && r.Header.Get("If-None-Match") != "*" {
+				http.Error(w, "If-Match or If-None-Match header required", http.StatusPreconditionRequired)
+				return
+			}
+
+			data, err := io.ReadAll(r.Body)
+
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			err = backend.Store(r.Context(), data, version)
+
+			if errors.Is(err, ErrConflict) {
+				http.Error(w, err.Error(), http.StatusPreconditionFailed)
+				return
+			}
+
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.Header().Set("Allow", "GET, PUT")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+}
@@ -0,0 +1,233 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"time"
+)
+
+// ErrConflict is returned by Backend.Store when the document was changed since it was loaded.
+var ErrConflict = errors.New("registry was changed concurrently")
+
+// Backend stores the registry document.  Updates use optimistic concurrency: the version returned by Load must be passed to Store, which
+// fails with ErrConflict if the document has been stored by someone else in the meantime.
+type Backend interface {
+	// Load returns the document (nil if there is none yet) and its version.
+	Load(ctx context.Context) (data []byte, version string, err error)
+	// Store replaces the document if its version is still the given version.
+	Store(ctx context.Context, data []byte, version string) error
+	// String describes the backend for error messages.
+	String() string
+}
+
+// Reservation is a name claimed by an owner (e.g. the identifier of a stack).
+type Reservation struct {
+	Owner      string    `json:"owner"`
+	ReservedAt time.Time `json:"reserved_at"`
+}
+
+// Sequence is the state of the numbers allocated for a prefix.
+type Sequence struct {
+	// Next is the number after the highest number ever allocated
+	Next int64 `json:"next"`
+	// Allocated are the numbers which are currently allocated
+	Allocated []int64 `json:"allocated"`
+}
+
+// Document is the content of the registry.
+type Document struct {
+	Reservations map[string]Reservation `json:"reservations"`
+	Sequences    map[string]Sequence    `json:"sequences,omitempty"`
+}
+
+// ReservedError is returned when a name is reserved by another owner.
+type ReservedError struct {
+	Name  string
+	Owner string
+}
+
+func (e *ReservedError) Error() string {
+	return fmt.Sprintf("name %q is already reserved by %q", e.Name, e.Owner)
+}
+
+// Registry provides the operations on the document of a backend.
+type Registry struct {
+	backend Backend
+}
+
+// updateAttempts is the number of times an update is retried when the document changes concurrently.
+const updateAttempts = 10
+
+func New(backend Backend) *Registry {
+	return &Registry{backend: backend}
+}
+
+// Read returns the current document.
+func (r *Registry) Read(ctx context.Context) (*Document, error) {
+	doc, _, err := r.load(ctx)
+	return doc, err
+}
+
+// Update applies fn to the current document and stores the result.  If the document was changed concurrently, it is loaded again and fn is
+// applied again.  Nothing is stored if fn returns an error.
+func (r *Registry) Update(ctx context.Context, fn func(doc *Document) error) error {
+	for attempt := 0; attempt < updateAttempts; attempt++ {
+		doc, version, err := r.load(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		if err := fn(doc); err != nil {
+			return err
+		}
+
+		data, err := json.MarshalIndent(doc, "", "  ")
+
+		if err != nil {
+			return fmt.Errorf("cannot encode registry %s: %v", r.backend, err)
+		}
+
+		err = r.backend.Store(ctx, data, version)
+
+		if !errors.Is(err, ErrConflict) {
+			return err
+		}
+
+		// wait a random time, so concurrent updates do not keep conflicting
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Duration(rand.Int64N(int64(attempt+1) * int64(20*time.Millisecond)))):
+		}
+	}
+
+	return fmt.Errorf("cannot update registry %s: %w after %d attempts", r.backend, ErrConflict, updateAttempts)
+}
+
+// Reserve claims the name for the owner.  Reserving a name again for the same owner succeeds.
+func (r *Registry) Reserve(ctx context.Context, name string, owner string) error {
+	return r.Update(ctx, func(doc *Document) error {
+		if reservation, exists := doc.Reservations[name]; exists {
+			if reservation.Owner != owner {
+				return &ReservedError{Name: name, Owner: reservation.Owner}
+			}
+
+			return nil
+		}
+
+		doc.Reservations[name] = Reservation{Owner: owner, ReservedAt: time.Now().UTC()}
+		return nil
+	})
+}
+
+// Release removes the reservation of the name if it is reserved by the owner.
+func (r *Registry) Release(ctx context.Context, name string, owner string) error {
+	return r.Update(ctx, func(doc *Document) error {
+		if reservation, exists := doc.Reservations[name]; exists && reservation.Owner == owner {
+			delete(doc.Reservations, name)
+		}
+
+		return nil
+	})
+}
+
+// Owner returns the owner of the name, or an empty string if it is not reserved.
+func (r *Registry) Owner(ctx context.Context, name string) (string, error) {
+	doc, err := r.Read(ctx)
+
+	if err != nil {
+		return "", err
+	}
+
+	return doc.Reservations[name].Owner, nil
+}
+
+// Allocate allocates a number of the sequence of the prefix which is at least start.  Unless reuse is true, numbers which were allocated
+// before are never allocated again, even if they were released.
+func (r *Registry) Allocate(ctx context.Context, prefix string, start int64, reuse bool) (int64, error) {
+	var number int64
+
+	err := r.Update(ctx, func(doc *Document) error {
+		sequence := doc.Sequences[prefix]
+		allocated := make(map[int64]bool, len(sequence.Allocated))
+
+		for _, n := range sequence.Allocated {
+			allocated[n] = true
+		}
+
+		number = start
+
+		if !reuse {
+			number = max(start, sequence.Next)
+		}
+
+		for allocated[number] {
+			number++
+		}
+
+		sequence.Allocated = append(sequence.Allocated, number)
+		slices.Sort(sequence.Allocated)
+		sequence.Next = max(sequence.Next, number+1)
+		doc.Sequences[prefix] = sequence
+
+		return nil
+	})
+
+	return number, err
+}
+
+// ReleaseNumber releases the number of the sequence of the prefix.
+func (r *Registry) ReleaseNumber(ctx context.Context, prefix string, number int64) error {
+	return r.Update(ctx, func(doc *Document) error {
+		sequence, exists := doc.Sequences[prefix]
+
+		if exists {
+			sequence.Allocated = slices.DeleteFunc(sequence.Allocated, func(n int64) bool { return n == number })
+			doc.Sequences[prefix] = sequence
+		}
+
+		return nil
+	})
+}
+
+// IsAllocated returns whether the number of the sequence of the prefix is allocated.
+func (r *Registry) IsAllocated(ctx context.Context, prefix string, number int64) (bool, error) {
+	doc, err := r.Read(ctx)
+
+	if err != nil {
+		return false, err
+	}
+
+	return slices.Contains(doc.Sequences[prefix].Allocated, number), nil
+}
+
+func (r *Registry) load(ctx context.Context) (*Document, string, error) {
+	data, version, err := r.backend.Load(ctx)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	doc := &Document{}
+
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, doc); err != nil {
+			return nil, "", fmt.Errorf("cannot decode registry %s: %v", r.backend, err)
+		}
+	}
+
+	if doc.Reservations == nil {
+		doc.Reservations = make(map[string]Reservation)
+	}
+
+	if doc.Sequences == nil {
+		doc.Sequences = make(map[string]Sequence)
+	}
+
+	return doc, version, nil
+}
@@ -0,0 +1,506 @@
+package registry_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"terraform-provider-namep/internal/registry"
+	"testing"
+	"time"
+)
+
+// backends returns a file backend and an HTTP backend serving another file with NewHandler.
+func backends(t *testing.T) map[string]registry.Backend {
+	dir := t.TempDir()
+	server := httptest.NewServer(registry.NewHandler(registry.NewFileBackend(filepath.Join(dir, "served.json"))))
+	t.Cleanup(server.Close)
+
+	return map[string]registry.Backend{
+		"file": registry.NewFileBackend(filepath.Join(dir, "registry.json")),
+		"http": registry.NewHTTPBackend(server.URL),
+	}
+}
+
+// conflictingBackend fails the first conflicts stores with ErrConflict.
+type conflictingBackend struct {
+	registry.Backend
+	conflicts int
+	stores    int
+}
+
+func (b *conflictingBackend) Store(ctx context.Context, data []byte, version string) error {
+	b.stores++
+
+	if b.stores <= b.conflicts {
+		return registry.ErrConflict
+	}
+
+	return b.Backend.Store(ctx, data, version)
+}
+
+func TestReserve_concurrent(t *testing.T) {
+	for name, backend := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			r := registry.New(backend)
+
+			var wg sync.WaitGroup
+			errs := make([]error, 10)
+
+			for i := range errs {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					errs[i] = r.Reserve(ctx, fmt.Sprintf("name-%d", i), fmt.Sprintf("owner-%d", i))
+				}()
+			}
+
+			wg.Wait()
+
+			for i, err := range errs {
+				if err != nil {
+					t.Errorf("reserving name-%d failed: %v", i, err)
+				}
+			}
+
+			doc, err := r.Read(ctx)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(doc.Reservations) != len(errs) {
+				t.Errorf("expected %d reservations, got %v", len(errs), doc.Reservations)
+			}
+
+			for i := range errs {
+				if owner := doc.Reservations[fmt.Sprintf("name-%d", i)].Owner; owner != fmt.Sprintf("owner-%d", i) {
+					t.Errorf("expected name-%d to be reserved by owner-%d, got %q", i, i, owner)
+				}
+			}
+		})
+	}
+}
+
+func TestReserve_concurrentSameName(t *testing.T) {
+	for name, backend := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			r := registry.New(backend)
+
+			var wg sync.WaitGroup
+			errs := make([]error, 10)
+
+			for i := range errs {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					errs[i] = r.Reserve(ctx, "shared", fmt.Sprintf("owner-%d", i))
+				}()
+			}
+
+			wg.Wait()
+
+			owner, err := r.Owner(ctx, "shared")
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			succeeded := 0
+
+			for i, err := range errs {
+				var reservedErr *registry.ReservedError
+
+				switch {
+				case err == nil:
+					succeeded++
+
+					if owner != fmt.Sprintf("owner-%d", i) {
+						t.Errorf("owner-%d reserved the name, but the owner is %q", i, owner)
+					}
+				case errors.As(err, &reservedErr):
+					if reservedErr.Name != "shared" || reservedErr.Owner != owner {
+						t.Errorf("expected the name to be reserved by %q, got %v", owner, reservedErr)
+					}
+				default:
+					t.Errorf("unexpected error: %v", err)
+				}
+			}
+
+			if succeeded != 1 {
+				t.Errorf("expected exactly one reservation to succeed, got %d", succeeded)
+			}
+		})
+	}
+}
+
+func TestReserve_reservedError(t *testing.T) {
+	ctx := context.Background()
+	r := registry.New(registry.NewFileBackend(filepath.Join(t.TempDir(), "registry.json")))
+
+	if err := r.Reserve(ctx, "name", "first"); err != nil {
+		t.Fatal(err)
+	}
+
+	// reserving again for the same owner succeeds
+	if err := r.Reserve(ctx, "name", "first"); err != nil {
+		t.Errorf("reserving again for the same owner failed: %v", err)
+	}
+
+	err := r.Reserve(ctx, "name", "second")
+
+	var reservedErr *registry.ReservedError
+	if !errors.As(err, &reservedErr) {
+		t.Fatalf("expected a ReservedError, got %v", err)
+	}
+
+	if reservedErr.Name != "name" || reservedErr.Owner != "first" {
+		t.Errorf("unexpected ReservedError: %+v", reservedErr)
+	}
+
+	if expected := `name "name" is already reserved by "first"`; err.Error() != expected {
+		t.Errorf("expected %q, got %q", expected, err.Error())
+	}
+
+	// only the owner can release the name
+	if err := r.Release(ctx, "name", "second"); err != nil {
+		t.Fatal(err)
+	}
+
+	if owner, _ := r.Owner(ctx, "name"); owner != "first" {
+		t.Errorf("expected the name to still be reserved by first, got %q", owner)
+	}
+
+	if err := r.Release(ctx, "name", "first"); err != nil {
+		t.Fatal(err)
+	}
+
+	if owner, _ := r.Owner(ctx, "name"); owner != "" {
+		t.Errorf("expected the name to be released, got owner %q", owner)
+	}
+}
+
+func TestUpdate_retriesOnConflict(t *testing.T) {
+	ctx := context.Background()
+	backend := &conflictingBackend{Backend: registry.NewFileBackend(filepath.Join(t.TempDir(), "registry.json")), conflicts: 2}
+	r := registry.New(backend)
+	calls := 0
+
+	err := r.Update(ctx, func(doc *registry.Document) error {
+		calls++
+		doc.Reservations["name"] = registry.Reservation{Owner: "owner"}
+		return nil
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if calls != 3 || backend.stores != 3 {
+		t.Errorf("expected 3 attempts, got %d updates and %d stores", calls, backend.stores)
+	}
+
+	if owner, _ := r.Owner(ctx, "name"); owner != "owner" {
+		t.Errorf("expected the name to be reserved by owner, got %q", owner)
+	}
+}
+
+func TestUpdate_givesUpOnConflict(t *testing.T) {
+	backend := &conflictingBackend{Backend: registry.NewFileBackend(filepath.Join(t.TempDir(), "registry.json")), conflicts: registry.UpdateAttempts}
+
+	err := registry.New(backend).Reserve(context.Background(), "name", "owner")
+
+	if !errors.Is(err, registry.ErrConflict) {
+		t.Errorf("expected ErrConflict, got %v", err)
+	}
+
+	if backend.stores != registry.UpdateAttempts {
+		t.Errorf("expected %d attempts, got %d", registry.UpdateAttempts, backend.stores)
+	}
+}
+
+func TestFileBackend_staleVersion(t *testing.T) {
+	ctx := context.Background()
+	backend := registry.NewFileBackend(filepath.Join(t.TempDir(), "registry.json"))
+
+	if err := backend.Store(ctx, []byte(`{"reservations":{}}`), ""); err != nil {
+		t.Fatal(err)
+	}
+
+	// the document exists now, so it cannot be created again
+	if err := backend.Store(ctx, []byte(`{"reservations":{}}`), ""); !errors.Is(err, registry.ErrConflict) {
+		t.Errorf("expected ErrConflict, got %v", err)
+	}
+
+	_, version, err := backend.Load(ctx)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := backend.Store(ctx, []byte(`{"reservations":{"a":{"owner":"x"}}}`), version); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := backend.Store(ctx, []byte(`{"reservations":{}}`), version); !errors.Is(err, registry.ErrConflict) {
+		t.Errorf("expected ErrConflict for a stale version, got %v", err)
+	}
+}
+
+func TestFileBackend_lock(t *testing.T) {
+	registry.SetLockTimeout(t, 300*time.Millisecond)
+
+	ctx := context.Background()
+	file := filepath.Join(t.TempDir(), "registry.json")
+	r := registry.New(registry.NewFileBackend(file))
+
+	if err := os.WriteFile(file+".lock", nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := r.Reserve(ctx, "name", "owner")
+
+	if err == nil || !strings.Contains(err.Error(), "still exists") {
+		t.Fatalf("expected the lock to time out, got %v", err)
+	}
+
+	// the lock of another run is not removed
+	if _, err := os.Stat(file + ".lock"); err != nil {
+		t.Errorf("expected the lock file to be kept: %v", err)
+	}
+
+	// the lock is taken as soon as it is released
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		os.Remove(file + ".lock")
+	}()
+
+	if err := r.Reserve(ctx, "name", "owner"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(file + ".lock"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected the lock file to be removed after the update: %v", err)
+	}
+
+	// the lock is not waited for after the context is done
+	if err := os.WriteFile(file+".lock", nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+
+	if err := r.Reserve(canceled, "other", "owner"); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the context error, got %v", err)
+	}
+}
+
+func TestHandler_preconditions(t *testing.T) {
+	server := httptest.NewServer(registry.NewHandler(registry.NewFileBackend(filepath.Join(t.TempDir(), "registry.json"))))
+	t.Cleanup(server.Close)
+
+	request := func(method string, header string, value string) *http.Response {
+		t.Helper()
+		req, err := http.NewRequest(method, server.URL, strings.NewReader(`{"reservations":{}}`))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if header != "" {
+			req.Header.Set(header, value)
+		}
+
+		resp, err := server.Client().Do(req)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+
+		return resp
+	}
+
+	if resp := request(http.MethodGet, "", ""); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected GET of a missing document to return 404, got %s", resp.Status)
+	}
+
+	if resp := request(http.MethodPut, "", ""); resp.StatusCode != http.StatusPreconditionRequired {
+		t.Errorf("expected PUT without a precondition to return 428, got %s", resp.Status)
+	}
+
+	if resp := request(http.MethodPut, "If-None-Match", "*"); resp.StatusCode != http.StatusNoContent {
+		t.Errorf("expected PUT with If-None-Match of a missing document to return 204, got %s", resp.Status)
+	}
+
+	if resp := request(http.MethodPut, "If-None-Match", "*"); resp.StatusCode != http.StatusPreconditionFailed {
+		t.Errorf("expected PUT with If-None-Match of an existing document to return 412, got %s", resp.Status)
+	}
+
+	resp := request(http.MethodGet, "", "")
+	etag := resp.Header.Get("ETag")
+
+	if resp.StatusCode != http.StatusOK || !strings.HasPrefix(etag, `"`) || !strings.HasSuffix(etag, `"`) {
+		t.Fatalf("expected GET to return 200 with a quoted ETag, got %s with %q", resp.Status, etag)
+	}
+
+	if resp := request(http.MethodPut, "If-Match", `"stale"`); resp.StatusCode != http.StatusPreconditionFailed {
+		t.Errorf("expected PUT with a stale If-Match to return 412, got %s", resp.Status)
+	}
+
+	if resp := request(http.MethodPut, "If-Match", etag); resp.StatusCode != http.StatusNoContent {
+		t.Errorf("expected PUT with the current If-Match to return 204, got %s", resp.Status)
+	}
+
+	if resp := request(http.MethodDelete, "", ""); resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("expected DELETE to return 405, got %s", resp.Status)
+	}
+}
+
+func TestHTTPBackend_timeout(t *testing.T) {
+	registry.SetHTTPTimeout(t, 100*time.Millisecond)
+
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	t.Cleanup(server.Close)
+	t.Cleanup(func() { close(done) })
+
+	_, err := registry.New(registry.NewHTTPBackend(server.URL)).Owner(context.Background(), "name")
+
+	if err == nil || !strings.Contains(err.Error(), "Timeout") {
+		t.Errorf("expected the request to time out, got %v", err)
+	}
+}
+
+func TestAllocate_neverReuses(t *testing.T) {
+	ctx := context.Background()
+	r := registry.New(registry.NewFileBackend(filepath.Join(t.TempDir(), "registry.json")))
+
+	allocate := func(prefix string, start int64, reuse bool, expected int64) {
+		t.Helper()
+		number, err := r.Allocate(ctx, prefix, start, reuse)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if number != expected {
+			t.Errorf("expected %d for %q, got %d", expected, prefix, number)
+		}
+	}
+
+	allocate("app", 1, false, 1)
+	allocate("app", 1, false, 2)
+	allocate("app", 1, false, 3)
+
+	if err := r.ReleaseNumber(ctx, "app", 2); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.ReleaseNumber(ctx, "app", 3); err != nil {
+		t.Fatal(err)
+	}
+
+	if allocated, _ := r.IsAllocated(ctx, "app", 2); allocated {
+		t.Error("expected 2 to be released")
+	}
+
+	// released numbers, including the highest, are not allocated again
+	allocate("app", 1, false, 4)
+
+	// a higher start skips ahead, a lower start does not go back
+	allocate("app", 10, false, 10)
+	allocate("app", 1, false, 11)
+
+	// sequences of other prefixes are independent
+	allocate("other", 1, false, 1)
+}
+
+func TestAllocate_reuse(t *testing.T) {
+	ctx := context.Background()
+	r := registry.New(registry.NewFileBackend(filepath.Join(t.TempDir(), "registry.json")))
+
+	for range 3 {
+		if _, err := r.Allocate(ctx, "app", 1, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := r.ReleaseNumber(ctx, "app", 2); err != nil {
+		t.Fatal(err)
+	}
+
+	// the lowest free number is allocated again
+	number, err := r.Allocate(ctx, "app", 1, true)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if number != 2 {
+		t.Errorf("expected the released number 2 to be reused, got %d", number)
+	}
+
+	number, err = r.Allocate(ctx, "app", 1, true)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if number != 4 {
+		t.Errorf("expected 4, got %d", number)
+	}
+}
+
+func TestAllocate_concurrent(t *testing.T) {
+	for name, backend := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			r := registry.New(backend)
+
+			var wg sync.WaitGroup
+			numbers := make([]int64, 10)
+			errs := make([]error, len(numbers))
+
+			for i := range numbers {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					numbers[i], errs[i] = r.Allocate(ctx, "app", 1, false)
+				}()
+			}
+
+			wg.Wait()
+
+			allocated := make(map[int64]bool)
+
+			for i, number := range numbers {
+				if errs[i] != nil {
+					t.Fatalf("allocation %d failed: %v", i, errs[i])
+				}
+
+				if allocated[number] {
+					t.Errorf("number %d was allocated twice", number)
+				}
+
+				allocated[number] = true
+			}
+
+			for number := int64(1); number <= int64(len(numbers)); number++ {
+				if !allocated[number] {
+					t.Errorf("expected %d to be allocated, got %v", number, numbers)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package resource
+
+import (
+	"context"
+
+	"terraform-provider-namep/internal/registry"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+type registryModel struct {
+	File types.String `tfsdk:"file"`
+	Url  types.String `tfsdk:"url"`
+}
+
+// registryAttribute is the attribute selecting the registry backend of the resources which store data in a registry.  Changing the registry
+// replaces the resource, so the data is removed from the old registry and added to the new one.
+func registryAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Description: "The registry to use.  Exactly one of `file` or `url` must be set.",
+		Required:    true,
+		Attributes: map[string]schema.Attribute{
+			"file": schema.StringAttribute{
+				Description: "Path of a local JSON file.  Updates are serialized with a lock file (the path with `.lock` appended), so the file can be shared by " +
+					"the runs on one machine or on a shared file system.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("url")),
+				},
+			},
+			"url": schema.StringAttribute{
+				Description: "URL of the registry document.  It is read with GET and written with PUT with an `If-Match` header containing the `ETag` of the " +
+					"GET response (or `If-None-Match: *` if the GET response was 404), which the server must reject with 412 if the document has changed.",
+				Optional: true,
+			},
+		},
+		PlanModifiers: []planmodifier.Object{
+			objectplanmodifier.RequiresReplace(),
+		},
+	}
+}
+
+// newRegistry returns the registry selected by the registry attribute.  If the attribute is not known yet, known is false.
+func newRegistry(ctx context.Context, obj types.Object, diags *diag.Diagnostics) (reg *registry.Registry, known bool) {
+	if obj.IsUnknown() {
+		return nil, false
+	}
+
+	var m registryModel
+	diags.Append(obj.As(ctx, &m, basetypes.ObjectAsOptions{})...)
+
+	if diags.HasError() || m.File.IsUnknown() || m.Url.IsUnknown() {
+		return nil, false
+	}
+
+	if !m.File.IsNull() {
+		return registry.New(registry.NewFileBackend(m.File.ValueString())), true
+	}
+
+	return registry.New(registry.NewHTTPBackend(m.Url.ValueString())), true
+}
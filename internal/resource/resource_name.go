@@ -0,0 +1,280 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+
+	namepf "terraform-provider-namep/internal/functions"
+	"terraform-provider-namep/internal/shared"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource               = &nameResource{}
+	_ resource.ResourceWithModifyPlan = &nameResource{}
+	_ resource.ResourceWithConfigure  = &nameResource{}
+)
+
+// New is a helper function to simplify the provider implementation.
+func NewName() resource.Resource {
+	return &nameResource{}
+}
+
+// resource implementation.
+type nameResource struct {
+	defaults types.Object
+}
+
+type nameResourceModel struct {
+	ResourceType  types.String `tfsdk:"resource_type"`
+	Configuration types.Object `tfsdk:"configuration"`
+	Overrides     types.Map    `tfsdk:"overrides"`
+	Keepers       types.Map    `tfsdk:"keepers"`
+	Name          types.String `tfsdk:"name"`
+	CurrentName   types.String `tfsdk:"current_name"`
+}
+
+func (r *nameResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_name"
+}
+
+func (r *nameResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `This resource generates a name the same way as the ` + "`namestring`" + ` function when it is created and keeps it in the state.  Since the ` + "`namestring`" + ` function
+computes the name on every run, changing a format, a type (e.g. a new version of the Azure CAF types) or a variable map (e.g. a new location short name) renames, and
+so usually recreates, the resources using it.  The name of this resource only changes when the resource is replaced, which happens when ` + "`resource_type`" + ` or
+` + "`keepers`" + ` change.
+
+## Drift
+
+The name the naming convention currently generates is in ` + "`current_name`" + `.  When it differs from ` + "`name`" + `, the plan contains a warning (and the change of
+` + "`current_name`" + `), so that changes of the convention are noticed.  To rename the resource, change a value in ` + "`keepers`" + `.`,
+		Attributes: map[string]schema.Attribute{
+			"resource_type": schema.StringAttribute{
+				Description: "Type of resource the name is for (used to select the format).  Changing it generates a new name.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"configuration": schema.ObjectAttribute{
+				Description:    "The configuration to generate the name with, usually from the `namep_configuration` data source.  Defaults to the default configuration of the provider.  Changing it does not change the name.",
+				Optional:       true,
+				AttributeTypes: namepf.ConfigurationAttributeTypes(),
+			},
+			"overrides": schema.MapAttribute{
+				Description: "Variable overrides, the same as the `overrides` of the `namestring` function.  Changing them does not change the name.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"keepers": schema.MapAttribute{
+				Description: "Arbitrary map of values which, when changed, generate a new name.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name generated when the resource was created.",
+				Computed:    true,
+			},
+			"current_name": schema.StringAttribute{
+				Description: "The name the configuration currently generates.  It is null if the name cannot be generated anymore.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *nameResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(shared.NamepConfig)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *NamepConfig, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.defaults = config.Configuration
+}
+
+func (r *nameResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to do when destroying
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan nameResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state *nameResourceModel
+
+	if !req.State.Raw.IsNull() {
+		state = &nameResourceModel{}
+		resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	currentName, diags := plan.generateName(ctx, r.defaults)
+
+	if state == nil || !plan.ResourceType.Equal(state.ResourceType) || !plan.Keepers.Equal(state.Keepers) {
+		// a new name is generated, so it must be possible to generate it
+		resp.Diagnostics.Append(diags...)
+		plan.Name = currentName
+	} else {
+		currentName = warnOnError(diags, currentName, &resp.Diagnostics)
+		plan.Name = state.Name
+
+		if !currentName.IsUnknown() && !currentName.IsNull() && !currentName.Equal(state.Name) {
+			resp.Diagnostics.AddAttributeWarning(path.Root("current_name"), "Name differs from the naming convention",
+				fmt.Sprintf("The name %q is kept, but the naming convention now generates %q for resource type %q.  Change a value of keepers to use the new name.",
+					state.Name.ValueString(), currentName.ValueString(), plan.ResourceType.ValueString()))
+		}
+	}
+
+	plan.CurrentName = currentName
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *nameResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan nameResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name, diags := plan.generateName(ctx, r.defaults)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if name.IsUnknown() {
+		resp.Diagnostics.AddError("Cannot generate name", fmt.Sprintf("The name for resource type %q depends on values which are still unknown.", plan.ResourceType.ValueString()))
+		return
+	}
+
+	plan.Name = name
+	plan.CurrentName = name
+
+	tflog.Trace(ctx, "created name resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *nameResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// the name only exists in the state, so there is nothing to refresh
+}
+
+func (r *nameResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state nameResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Name = state.Name
+
+	if plan.CurrentName.IsUnknown() {
+		currentName, diags := plan.generateName(ctx, r.defaults)
+		plan.CurrentName = warnOnError(diags, currentName, &resp.Diagnostics)
+
+		if plan.CurrentName.IsUnknown() {
+			plan.CurrentName = types.StringNull()
+		}
+	}
+
+	tflog.Trace(ctx, "updated name resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *nameResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// removing the resource from the state is all there is to do
+}
+
+// generateName generates the name for the model with the namestring logic, using the defaults if the model has no configuration.  The name is
+// unknown if any value needed is unknown.
+func (m *nameResourceModel) generateName(ctx context.Context, defaults types.Object) (types.String, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if m.ResourceType.IsUnknown() || m.Overrides.IsUnknown() {
+		return types.StringUnknown(), diags
+	}
+
+	configuration := m.Configuration
+
+	if configuration.IsNull() {
+		if defaults.IsNull() {
+			diags.AddAttributeError(path.Root("configuration"), "No configuration", "The configuration must be set when the provider has no default configuration.")
+			return types.StringUnknown(), diags
+		}
+
+		configuration = defaults
+	}
+
+	overrides := make(map[string]string)
+
+	if !m.Overrides.IsNull() {
+		diags.Append(m.Overrides.ElementsAs(ctx, &overrides, false)...)
+
+		if diags.HasError() {
+			return types.StringUnknown(), diags
+		}
+	}
+
+	name, known, diags := namepf.GenerateName(ctx, configuration, m.ResourceType.ValueString(), overrides)
+
+	if diags.HasError() || !known {
+		return types.StringUnknown(), diags
+	}
+
+	return types.StringValue(name), diags
+}
+
+// warnOnError reports the errors of generating the current name of an existing resource as warnings, since the name in the state is still
+// valid.  The current name is null if it cannot be generated.
+func warnOnError(diags diag.Diagnostics, currentName types.String, target *diag.Diagnostics) types.String {
+	if !diags.HasError() {
+		return currentName
+	}
+
+	for _, d := range diags {
+		target.AddAttributeWarning(path.Root("current_name"), d.Summary(), d.Detail())
+	}
+
+	return types.StringNull()
+}
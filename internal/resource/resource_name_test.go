@@ -0,0 +1,118 @@
+package resource_test
+
+import (
+	"fmt"
+	"regexp"
+	"terraform-provider-namep/internal/acctest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func nameConfig(format string, keeper string) string {
+	return fmt.Sprintf(`data "namep_configuration" "example" {
+	  formats = {
+	    custom = %q
+	  }
+	  variables = {
+	    app = "myapp"
+	  }
+	}
+
+	resource "namep_name" "example" {
+	  resource_type = "my_type"
+	  configuration = data.namep_configuration.example.configuration
+	  overrides = {
+	    name = "main"
+	  }
+	  keepers = {
+	    generation = %q
+	  }
+	}`, format, keeper)
+}
+
+func TestAccResourceName_stable(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: nameConfig("#{APP}-#{NAME}", "1"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("namep_name.example", tfjsonpath.New("name"), knownvalue.StringExact("myapp-main")),
+					statecheck.ExpectKnownValue("namep_name.example", tfjsonpath.New("current_name"), knownvalue.StringExact("myapp-main")),
+				},
+			},
+			{
+				// the convention changes, but the name is kept
+				Config: nameConfig("#{NAME}-#{APP}", "1"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("namep_name.example", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("namep_name.example", tfjsonpath.New("name"), knownvalue.StringExact("myapp-main")),
+					statecheck.ExpectKnownValue("namep_name.example", tfjsonpath.New("current_name"), knownvalue.StringExact("main-myapp")),
+				},
+			},
+			{
+				// changing the keepers generates the new name
+				Config: nameConfig("#{NAME}-#{APP}", "2"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("namep_name.example", plancheck.ResourceActionReplace),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("namep_name.example", tfjsonpath.New("name"), knownvalue.StringExact("main-myapp")),
+				},
+			},
+		},
+	})
+}
+
+func TestAccResourceName_provider_defaults(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `provider "namep" {
+				  formats = {
+				    custom = "#{APP}-#{NAME}"
+				  }
+				  variables = {
+				    app = "myapp"
+				  }
+				}
+
+				resource "namep_name" "example" {
+				  resource_type = "my_type"
+				  overrides = {
+				    name = "main"
+				  }
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("namep_name.example", tfjsonpath.New("name"), knownvalue.StringExact("myapp-main")),
+				},
+			},
+		},
+	})
+}
+
+func TestAccResourceName_no_configuration(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `resource "namep_name" "example" {
+				  resource_type = "my_type"
+				}`,
+				ExpectError: regexp.MustCompile(`The\s+configuration\s+must\s+be\s+set`),
+			},
+		},
+	})
+}
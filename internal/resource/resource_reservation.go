@@ -0,0 +1,186 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"terraform-provider-namep/internal/registry"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource               = &reservationResource{}
+	_ resource.ResourceWithModifyPlan = &reservationResource{}
+)
+
+// New is a helper function to simplify the provider implementation.
+func NewReservation() resource.Resource {
+	return &reservationResource{}
+}
+
+// resource implementation.
+type reservationResource struct{}
+
+type reservationResourceModel struct {
+	Name     types.String `tfsdk:"name"`
+	Owner    types.String `tfsdk:"owner"`
+	Registry types.Object `tfsdk:"registry"`
+}
+
+func (r *reservationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_reservation"
+}
+
+func (r *reservationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `This resource claims a name in a registry shared by several stacks, so that two stacks cannot use the same name (e.g. for a resource whose name
+must be globally unique).  Creating a reservation for a name which is reserved by another owner fails (when planning if the name is known, and otherwise when
+applying) with the owner of the name.  Reserving a name again with the same owner succeeds, so a stack can be recreated.  Destroying the resource releases the name.
+
+If the reservation of a name is removed from the registry or taken over by another owner, the resource is removed from the state, so the next plan tries to
+reserve the name again.`,
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Description: "The name to reserve, usually generated by the `namestring` function or the `namep_name` resource.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"owner": schema.StringAttribute{
+				Description: "Identifier of the owner of the reservation (e.g. the name of the stack), which is reported when another owner tries to reserve the name.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"registry": registryAttribute(),
+		},
+	}
+}
+
+func (r *reservationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// only creating a reservation can fail
+	if req.Plan.Raw.IsNull() || !req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan reservationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() || plan.Name.IsUnknown() || plan.Owner.IsUnknown() || plan.Registry.IsUnknown() {
+		return
+	}
+
+	reg, known := newRegistry(ctx, plan.Registry, &resp.Diagnostics)
+
+	if !known {
+		return
+	}
+
+	owner, err := reg.Owner(ctx, plan.Name.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("registry"), "Failed to read registry", err.Error())
+		return
+	}
+
+	if owner != "" && owner != plan.Owner.ValueString() {
+		resp.Diagnostics.AddAttributeError(path.Root("name"), "Name already reserved", (&registry.ReservedError{Name: plan.Name.ValueString(), Owner: owner}).Error())
+	}
+}
+
+func (r *reservationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan reservationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reg, _ := newRegistry(ctx, plan.Registry, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := reg.Reserve(ctx, plan.Name.ValueString(), plan.Owner.ValueString())
+
+	var reservedErr *registry.ReservedError
+
+	if errors.As(err, &reservedErr) {
+		resp.Diagnostics.AddAttributeError(path.Root("name"), "Name already reserved", err.Error())
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("registry"), "Failed to reserve name", err.Error())
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("reserved name %q", plan.Name.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *reservationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state reservationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reg, _ := newRegistry(ctx, state.Registry, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	owner, err := reg.Owner(ctx, state.Name.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("registry"), "Failed to read registry", err.Error())
+		return
+	}
+
+	if owner != state.Owner.ValueString() {
+		tflog.Warn(ctx, fmt.Sprintf("name %q is not reserved by %q anymore, removing it from the state", state.Name.ValueString(), state.Owner.ValueString()))
+		resp.State.RemoveResource(ctx)
+	}
+}
+
+func (r *reservationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// every attribute requires replacement, so there is nothing to update
+	var plan reservationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *reservationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state reservationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reg, _ := newRegistry(ctx, state.Registry, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := reg.Release(ctx, state.Name.ValueString(), state.Owner.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("registry"), "Failed to release name", err.Error())
+	}
+}
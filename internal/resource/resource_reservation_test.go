@@ -0,0 +1,103 @@
+package resource_test
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"terraform-provider-namep/internal/acctest"
+	"terraform-provider-namep/internal/registry"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func reservationConfig(registryAttrs string, name string, owner string) string {
+	return fmt.Sprintf(`resource "namep_reservation" "example" {
+	  name     = %q
+	  owner    = %q
+	  registry = { %s }
+	}`, name, owner, registryAttrs)
+}
+
+func checkOwner(reg *registry.Registry, name string, want string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		owner, err := reg.Owner(context.Background(), name)
+
+		if err != nil {
+			return err
+		}
+
+		if owner != want {
+			return fmt.Errorf("name %q is reserved by %q, expected %q", name, owner, want)
+		}
+
+		return nil
+	}
+}
+
+func TestAccResourceReservation_file(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "registry.json")
+	reg := registry.New(registry.NewFileBackend(file))
+	registryAttrs := fmt.Sprintf("file = %q", file)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		CheckDestroy:             checkOwner(reg, "stmyappprd", ""),
+		Steps: []resource.TestStep{
+			{
+				Config: reservationConfig(registryAttrs, "stmyappprd", "stack-a"),
+				Check:  checkOwner(reg, "stmyappprd", "stack-a"),
+			},
+		},
+	})
+}
+
+func TestAccResourceReservation_conflict(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "registry.json")
+	registryAttrs := fmt.Sprintf("file = %q", file)
+
+	err := os.WriteFile(file, []byte(`{"reservations": {"stmyappprd": {"owner": "stack-b", "reserved_at": "2024-01-01T00:00:00Z"}}}`), 0644)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      reservationConfig(registryAttrs, "stmyappprd", "stack-a"),
+				ExpectError: regexp.MustCompile(`name\s+"stmyappprd"\s+is\s+already\s+reserved\s+by\s+"stack-b"`),
+			},
+		},
+	})
+}
+
+func TestAccResourceReservation_http(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "registry.json")
+	server := httptest.NewServer(registry.NewHandler(registry.NewFileBackend(file)))
+	defer server.Close()
+
+	reg := registry.New(registry.NewHTTPBackend(server.URL))
+	registryAttrs := fmt.Sprintf("url = %q", server.URL)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		CheckDestroy:             checkOwner(reg, "kv-myapp-prd", ""),
+		Steps: []resource.TestStep{
+			{
+				Config: reservationConfig(registryAttrs, "kv-myapp-prd", "stack-a"),
+				Check:  checkOwner(reg, "kv-myapp-prd", "stack-a"),
+			},
+			{
+				// another owner cannot take the name
+				Config:      reservationConfig(registryAttrs, "kv-myapp-prd", "stack-b"),
+				ExpectError: regexp.MustCompile(`already\s+reserved\s+by\s+"stack-a"`),
+			},
+		},
+	})
+}
@@ -0,0 +1,253 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource               = &sequenceResource{}
+	_ resource.ResourceWithModifyPlan = &sequenceResource{}
+)
+
+// New is a helper function to simplify the provider implementation.
+func NewSequence() resource.Resource {
+	return &sequenceResource{}
+}
+
+// resource implementation.
+type sequenceResource struct{}
+
+type sequenceResourceModel struct {
+	Prefix    types.String `tfsdk:"prefix"`
+	Registry  types.Object `tfsdk:"registry"`
+	Start     types.Int64  `tfsdk:"start"`
+	Padding   types.Int64  `tfsdk:"padding"`
+	Reuse     types.Bool   `tfsdk:"reuse"`
+	Variable  types.String `tfsdk:"variable"`
+	Number    types.Int64  `tfsdk:"number"`
+	Formatted types.String `tfsdk:"formatted"`
+	Variables types.Map    `tfsdk:"variables"`
+}
+
+func (r *sequenceResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_sequence"
+}
+
+func (r *sequenceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `This resource allocates the next free number of a sequence for names which are numbered (e.g. ` + "`vm-myapp-prd-weu-001`" + `).  The allocations are stored in a
+registry (the same as the one of the [namep_reservation](reservation.md) resource), so every stack using the same registry and prefix gets a different number.  Destroying
+the resource releases the number, but released numbers are only allocated again if ` + "`reuse`" + ` is true.
+
+The number is provided as a variable in ` + "`variables`" + `, which can be passed as an override to the ` + "`namestring`" + ` function:
+
+` + "```terraform" + `
+resource "namep_sequence" "vm" {
+  prefix   = "vm-myapp-prd-weu"
+  registry = { file = "${path.root}/registry.json" }
+}
+
+locals {
+  vm_name = provider::namep::namestring("azurerm_linux_virtual_machine", data.namep_configuration.example.configuration, namep_sequence.vm.variables)
+}
+` + "```" + `
+
+with a format like ` + "`#{SLUG}-#{APP}-#{ENV}-#{LOCS[LOC]}-#{INSTANCE}`" + `.`,
+		Attributes: map[string]schema.Attribute{
+			"prefix": schema.StringAttribute{
+				Description: "The prefix the sequence is for, usually the name without the number.  Every prefix has its own sequence.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"registry": registryAttribute(),
+			"start": schema.Int64Attribute{
+				Description: "The lowest number to allocate, defaults to 1.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(1),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"padding": schema.Int64Attribute{
+				Description: "The number of digits of `formatted`, which is padded with zeros, defaults to 3.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(3),
+				Validators: []validator.Int64{
+					int64validator.Between(1, 20),
+				},
+			},
+			"reuse": schema.BoolAttribute{
+				Description: "If true, the lowest released number is allocated instead of a number which was never allocated before, defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"variable": schema.StringAttribute{
+				Description: "The name of the variable in `variables`, defaults to \"instance\".",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("instance"),
+			},
+			"number": schema.Int64Attribute{
+				Description: "The allocated number.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"formatted": schema.StringAttribute{
+				Description: "The allocated number padded with zeros to `padding` digits.",
+				Computed:    true,
+			},
+			"variables": schema.MapAttribute{
+				Description: "A map with the variable named by `variable` set to `formatted`, to be used as an override of the `namestring` function.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (r *sequenceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to do when destroying
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan sequenceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// when the number is kept, the formatted number is known when planning
+	plan.setFormatted(ctx)
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *sequenceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan sequenceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reg, _ := newRegistry(ctx, plan.Registry, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	number, err := reg.Allocate(ctx, plan.Prefix.ValueString(), plan.Start.ValueInt64(), plan.Reuse.ValueBool())
+
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("registry"), "Failed to allocate number", err.Error())
+		return
+	}
+
+	plan.Number = types.Int64Value(number)
+	plan.setFormatted(ctx)
+
+	tflog.Trace(ctx, fmt.Sprintf("allocated number %d for prefix %q", number, plan.Prefix.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *sequenceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state sequenceResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reg, _ := newRegistry(ctx, state.Registry, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	allocated, err := reg.IsAllocated(ctx, state.Prefix.ValueString(), state.Number.ValueInt64())
+
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("registry"), "Failed to read registry", err.Error())
+		return
+	}
+
+	if !allocated {
+		tflog.Warn(ctx, fmt.Sprintf("number %d of prefix %q is not allocated anymore, removing it from the state", state.Number.ValueInt64(), state.Prefix.ValueString()))
+		resp.State.RemoveResource(ctx)
+	}
+}
+
+func (r *sequenceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state sequenceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Number = state.Number
+	plan.setFormatted(ctx)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *sequenceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state sequenceResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reg, _ := newRegistry(ctx, state.Registry, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := reg.ReleaseNumber(ctx, state.Prefix.ValueString(), state.Number.ValueInt64()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("registry"), "Failed to release number", err.Error())
+	}
+}
+
+// setFormatted sets the attributes derived from the number, which are unknown if the number or the settings are unknown.
+func (m *sequenceResourceModel) setFormatted(ctx context.Context) {
+	if m.Number.IsUnknown() || m.Padding.IsUnknown() || m.Variable.IsUnknown() {
+		m.Formatted = types.StringUnknown()
+		m.Variables = types.MapUnknown(types.StringType)
+		return
+	}
+
+	formatted := fmt.Sprintf("%0*d", int(m.Padding.ValueInt64()), m.Number.ValueInt64())
+	m.Formatted = types.StringValue(formatted)
+	m.Variables, _ = types.MapValueFrom(ctx, types.StringType, map[string]string{m.Variable.ValueString(): formatted})
+}
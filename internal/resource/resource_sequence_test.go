@@ -0,0 +1,122 @@
+package resource_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"terraform-provider-namep/internal/acctest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccResourceSequence_allocate(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "registry.json")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`data "namep_configuration" "example" {
+				  formats = {
+				    custom = "vm-#{APP}-#{INSTANCE}"
+				  }
+				  variables = {
+				    app = "myapp"
+				  }
+				}
+
+				resource "namep_sequence" "first" {
+				  prefix   = "vm-myapp"
+				  registry = { file = %[1]q }
+				}
+
+				resource "namep_sequence" "second" {
+				  prefix   = "vm-myapp"
+				  registry = { file = %[1]q }
+				  padding  = 2
+
+				  depends_on = [namep_sequence.first]
+				}
+
+				output "name" {
+				  value = provider::namep::namestring("my_vm", data.namep_configuration.example.configuration, namep_sequence.second.variables)
+				}`, file),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("namep_sequence.first", tfjsonpath.New("number"), knownvalue.Int64Exact(1)),
+					statecheck.ExpectKnownValue("namep_sequence.first", tfjsonpath.New("formatted"), knownvalue.StringExact("001")),
+					statecheck.ExpectKnownValue("namep_sequence.second", tfjsonpath.New("number"), knownvalue.Int64Exact(2)),
+					statecheck.ExpectKnownValue("namep_sequence.second", tfjsonpath.New("variables"), knownvalue.MapExact(map[string]knownvalue.Check{
+						"instance": knownvalue.StringExact("02"),
+					})),
+					statecheck.ExpectKnownOutputValue("name", knownvalue.StringExact("vm-myapp-02")),
+				},
+			},
+			{
+				// released numbers are not allocated again
+				Config: fmt.Sprintf(`resource "namep_sequence" "second" {
+				  prefix   = "vm-myapp"
+				  registry = { file = %[1]q }
+				  padding  = 2
+				}
+
+				resource "namep_sequence" "third" {
+				  prefix   = "vm-myapp"
+				  registry = { file = %[1]q }
+
+				  depends_on = [namep_sequence.second]
+				}`, file),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("namep_sequence.second", tfjsonpath.New("number"), knownvalue.Int64Exact(2)),
+					statecheck.ExpectKnownValue("namep_sequence.third", tfjsonpath.New("number"), knownvalue.Int64Exact(3)),
+				},
+			},
+		},
+	})
+}
+
+func TestAccResourceSequence_reuse(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "registry.json")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`resource "namep_sequence" "first" {
+				  prefix   = "vm-myapp"
+				  registry = { file = %[1]q }
+				}
+
+				resource "namep_sequence" "second" {
+				  prefix   = "vm-myapp"
+				  registry = { file = %[1]q }
+
+				  depends_on = [namep_sequence.first]
+				}`, file),
+			},
+			{
+				Config: fmt.Sprintf(`resource "namep_sequence" "second" {
+				  prefix   = "vm-myapp"
+				  registry = { file = %[1]q }
+				}
+
+				resource "namep_sequence" "third" {
+				  prefix   = "vm-myapp"
+				  registry = { file = %[1]q }
+				  reuse    = true
+				  variable = "number"
+
+				  depends_on = [namep_sequence.second]
+				}`, file),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("namep_sequence.third", tfjsonpath.New("number"), knownvalue.Int64Exact(1)),
+					statecheck.ExpectKnownValue("namep_sequence.third", tfjsonpath.New("variables"), knownvalue.MapExact(map[string]knownvalue.Check{
+						"number": knownvalue.StringExact("001"),
+					})),
+				},
+			},
+		},
+	})
+}
@@ -1,7 +1,12 @@
 package shared
 
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
 type NamepConfig struct {
 	Static bool
+
+	// Configuration is the default configuration of the provider, null if the provider configures none.
+	Configuration types.Object
 }
 
 type TypeFields struct {
@@ -11,5 +16,6 @@ type TypeFields struct {
 	MaxLength       int    `tfsdk:"max_length"`
 	Lowercase       bool   `tfsdk:"lowercase"`
 	ValidationRegex string `tfsdk:"validation_regex"`
+	CleanupRegex    string `tfsdk:"cleanup_regex"`
 	DefaultSelector string `tfsdk:"default_selector"`
 }
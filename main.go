@@ -18,6 +18,8 @@ import (
 // Generate the model files
 //go:generate go run tools/azure/genLocations.go
 //go:generate go run tools/azure/gen.go
+//go:generate go run tools/cloud/gen.go aws
+//go:generate go run tools/cloud/gen.go gcp
 
 // Run the docs generation tool, check its repository for more information on how it works and how docs
 // can be customized.
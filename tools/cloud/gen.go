@@ -0,0 +1,77 @@
+// The following directive is necessary to make the package coherent:
+
+//go:build ignore
+// +build ignore
+
+// This program generates internal/cloud/<cloud>/models_generated.go for the AWS and GCP datasets, from
+// tools/<cloud>/data/resourceDefinition.json. It can be invoked by running
+// go generate
+// or for a single dataset
+// go run tools/cloud/gen.go aws
+
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"path"
+	"sort"
+	"terraform-provider-namep/internal/cloud"
+	"text/template"
+	"time"
+)
+
+type templateData struct {
+	Package            string
+	ResourceStructures []cloud.ResourceStructure
+	GeneratedTime      time.Time
+}
+
+func main() {
+	if len(os.Args) != 2 || (os.Args[1] != "aws" && os.Args[1] != "gcp") {
+		log.Fatalln("Usage: go run tools/cloud/gen.go aws|gcp")
+	}
+
+	name := os.Args[1]
+
+	wd, err := os.Getwd()
+	if err != nil {
+		log.Panicln("No directory found")
+	}
+
+	parsedTemplate, err := template.ParseFiles(path.Join(wd, "tools/cloud/templates/model.tmpl"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	sourceDefinitions, err := os.ReadFile(path.Join(wd, "tools", name, "data/resourceDefinition.json"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var data []cloud.ResourceStructure
+	err = json.Unmarshal(sourceDefinitions, &data)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	sort.SliceStable(data, func(i, j int) bool {
+		return data[i].ResourceTypeName < data[j].ResourceTypeName
+	})
+
+	modelsFile, err := os.OpenFile(path.Join(wd, "internal/cloud", name, "models_generated.go"), os.O_TRUNC|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = parsedTemplate.ExecuteTemplate(modelsFile, "model.tmpl", templateData{
+		Package:            name,
+		GeneratedTime:      time.Now(),
+		ResourceStructures: data,
+	})
+
+	if err != nil {
+		log.Fatalf("execution failed: %s", err)
+	}
+	log.Printf("File generated for %s", name)
+}
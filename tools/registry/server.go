@@ -0,0 +1,24 @@
+//go:build ignore
+// +build ignore
+
+// This program serves a file registry over HTTP for the url of the registry of the namep_reservation and namep_sequence resources.
+// It can be invoked by running
+// go run tools/registry/server.go -file registry.json -addr localhost:8080
+
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"terraform-provider-namep/internal/registry"
+)
+
+func main() {
+	file := flag.String("file", "registry.json", "path of the registry file")
+	addr := flag.String("addr", "localhost:8080", "address to listen on")
+	flag.Parse()
+
+	log.Printf("serving registry file %q on http://%s/", *file, *addr)
+	log.Fatal(http.ListenAndServe(*addr, registry.NewHandler(registry.NewFileBackend(*file))))
+}